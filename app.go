@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"driver-box/pkg/installer"
 	"fmt"
 	"io"
 	"net/http"
@@ -64,6 +65,32 @@ func (a *App) SelectFile(relative bool) (string, error) {
 
 }
 
+// Detects the type of an installer, and suggests the flags and exit codes to run it silently.
+// A relative path is resolved against the directory of the executable.
+func (a App) AnalyzeInstaller(path string) (installer.Analysis, error) {
+	if !filepath.IsAbs(path) {
+		if exePath, err := os.Executable(); err != nil {
+			return installer.Analysis{}, err
+		} else {
+			path = filepath.Join(filepath.Dir(exePath), path)
+		}
+	}
+	return installer.AnalyzeFile(path)
+}
+
+// Reads the Authenticode signature of an executable and checks the file against it.
+// A relative path is resolved against the directory of the executable.
+func (a App) InspectSignature(path string) (installer.Signature, error) {
+	if !filepath.IsAbs(path) {
+		if exePath, err := os.Executable(); err != nil {
+			return installer.Signature{}, err
+		} else {
+			path = filepath.Join(filepath.Dir(exePath), path)
+		}
+	}
+	return installer.InspectSignatureFile(path)
+}
+
 func (a App) PathExists(path string) bool {
 	_, err := os.Stat(path)
 	return err != nil
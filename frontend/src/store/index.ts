@@ -29,7 +29,9 @@ export const useAppSettingStore = defineStore('appSetting', () => {
     write: () =>
       appManager
         .Update(settings.value)
-        .then(() => (original.value = structuredClone(toRaw(settings.value))))
+        .then(() => (original.value = structuredClone(toRaw(settings.value)))),
+    // applies the settings for the rest of the session without saving them
+    override: () => appManager.Override(settings.value)
   }
 })
 
@@ -66,6 +68,13 @@ export const useDriverGroupStore = defineStore('driverGroup', () => {
         .Read()
         .then(g => (groups.value = g))
         .finally(() => (loading.value = false))
+    },
+    refreshMetadata: async () => {
+      loading.value = true
+      return groupManger
+        .RefreshMetadata()
+        .then(g => (groups.value = g))
+        .finally(() => (loading.value = false))
     }
   }
 })
@@ -1,28 +1,22 @@
-import type { status } from '@/wailsjs/go/models'
+import type { execute, status } from '@/wailsjs/go/models'
 
 export type Command = {
   id: string
   name?: string
   groupName: string
-  config: {
-    program: string
-    options: Array<string>
-    minExeTime: number
-    allowRtCodes: Array<number>
-    incompatibles: Array<string>
+  // driver run by the executor with its kind, exit codes and output rules, or a built-in task
+  driver?: {
+    groupId: string
+    driverId: string
   }
+  taskId?: string
+  minExeTime: number
+  incompatibles: Array<string>
 }
 
 export type Process = {
   command: Command
   status: status.Status
   procId?: string
-  result?: {
-    lapse: number
-    exitCode: number
-    stdout: string
-    stderr: string
-    error: string
-    aborted: boolean
-  }
+  result?: execute.CommandResult
 }
@@ -2,11 +2,26 @@
 // This file is automatically generated. DO NOT EDIT
 import {execute} from '../models';
 import {context} from '../models';
+import {status} from '../models';
 
 export function Abort(arg1:string):Promise<void>;
 
-export function Run(arg1:string,arg2:Array<string>):Promise<string>;
+export function Evict(arg1:string):Promise<void>;
+
+export function Inspect(arg1:string):Promise<execute.CommandInfo>;
+
+export function List():Promise<Array<execute.CommandInfo>>;
+
+export function Result(arg1:string):Promise<execute.CommandResult>;
+
+export function Run(arg1:string,arg2:Array<string>,arg3:number):Promise<string>;
 
 export function RunAndOutput(arg1:string,arg2:Array<string>,arg3:boolean):Promise<execute.CommandResult>;
 
+export function RunDriver(arg1:string,arg2:string):Promise<string>;
+
+export function RunTask(arg1:string):Promise<string>;
+
 export function SetContext(arg1:context.Context):Promise<void>;
+
+export function Summary(arg1:Array<string>):Promise<status.Summary>;
@@ -6,14 +6,42 @@ export function Abort(arg1) {
   return window['go']['execute']['CommandExecutor']['Abort'](arg1);
 }
 
-export function Run(arg1, arg2) {
-  return window['go']['execute']['CommandExecutor']['Run'](arg1, arg2);
+export function Evict(arg1) {
+  return window['go']['execute']['CommandExecutor']['Evict'](arg1);
+}
+
+export function Inspect(arg1) {
+  return window['go']['execute']['CommandExecutor']['Inspect'](arg1);
+}
+
+export function List() {
+  return window['go']['execute']['CommandExecutor']['List']();
+}
+
+export function Result(arg1) {
+  return window['go']['execute']['CommandExecutor']['Result'](arg1);
+}
+
+export function Run(arg1, arg2, arg3) {
+  return window['go']['execute']['CommandExecutor']['Run'](arg1, arg2, arg3);
 }
 
 export function RunAndOutput(arg1, arg2, arg3) {
   return window['go']['execute']['CommandExecutor']['RunAndOutput'](arg1, arg2, arg3);
 }
 
+export function RunDriver(arg1, arg2) {
+  return window['go']['execute']['CommandExecutor']['RunDriver'](arg1, arg2);
+}
+
+export function RunTask(arg1) {
+  return window['go']['execute']['CommandExecutor']['RunTask'](arg1);
+}
+
 export function SetContext(arg1) {
   return window['go']['execute']['CommandExecutor']['SetContext'](arg1);
 }
+
+export function Summary(arg1) {
+  return window['go']['execute']['CommandExecutor']['Summary'](arg1);
+}
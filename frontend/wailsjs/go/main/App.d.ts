@@ -1,7 +1,10 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
+import {installer} from '../models';
 import {context} from '../models';
 
+export function AnalyzeInstaller(arg1:string):Promise<installer.Analysis>;
+
 export function AppBinaryType():Promise<string>;
 
 export function AppConfigPath():Promise<string>;
@@ -14,6 +17,8 @@ export function Cwd():Promise<string>;
 
 export function ExecutableExists(arg1:string):Promise<boolean>;
 
+export function InspectSignature(arg1:string):Promise<installer.Signature>;
+
 export function PathExists(arg1:string):Promise<boolean>;
 
 export function SelectFile(arg1:boolean):Promise<string>;
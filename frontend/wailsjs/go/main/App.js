@@ -2,6 +2,10 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AnalyzeInstaller(arg1) {
+  return window['go']['main']['App']['AnalyzeInstaller'](arg1);
+}
+
 export function AppBinaryType() {
   return window['go']['main']['App']['AppBinaryType']();
 }
@@ -26,6 +30,10 @@ export function ExecutableExists(arg1) {
   return window['go']['main']['App']['ExecutableExists'](arg1);
 }
 
+export function InspectSignature(arg1) {
+  return window['go']['main']['App']['InspectSignature'](arg1);
+}
+
 export function PathExists(arg1) {
   return window['go']['main']['App']['PathExists'](arg1);
 }
@@ -1,5 +1,130 @@
 export namespace execute {
 	
+	export class Event {
+	    // Go type: time
+	    time: any;
+	    type: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Event(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = this.convertValues(source["time"], null);
+	        this.type = source["type"];
+	        this.message = source["message"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CommandInfo {
+	    id: string;
+	    program: string;
+	    options: string[];
+	    state: string;
+	    status: status.Status;
+	    pid: number;
+	    // Go type: time
+	    startTime: any;
+	    // Go type: time
+	    exitTime: any;
+	    lapse: number;
+	    attempts: number;
+	    events: Event[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CommandInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.program = source["program"];
+	        this.options = source["options"];
+	        this.state = source["state"];
+	        this.status = source["status"];
+	        this.pid = source["pid"];
+	        this.startTime = this.convertValues(source["startTime"], null);
+	        this.exitTime = this.convertValues(source["exitTime"], null);
+	        this.lapse = source["lapse"];
+	        this.attempts = source["attempts"];
+	        this.events = this.convertValues(source["events"], Event);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class OutputMatch {
+	    rule: number;
+	    source: string;
+	    line: string;
+	    outcome: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new OutputMatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rule = source["rule"];
+	        this.source = source["source"];
+	        this.line = source["line"];
+	        this.outcome = source["outcome"];
+	    }
+	}
+	export class Usage {
+	    cpu: number;
+	    memory: number;
+	    read: number;
+	    write: number;
+	    children: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Usage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.cpu = source["cpu"];
+	        this.memory = source["memory"];
+	        this.read = source["read"];
+	        this.write = source["write"];
+	        this.children = source["children"];
+	    }
+	}
 	export class CommandResult {
 	    lapse: number;
 	    exitCode: number;
@@ -7,6 +132,15 @@ export namespace execute {
 	    stderr: string;
 	    error: string;
 	    aborted: boolean;
+	    status: status.Status;
+	    events: Event[];
+	    peak: Usage;
+	    match: OutputMatch;
+	    stdoutLog: string;
+	    stderrLog: string;
+	    installerLog: string;
+	    // Go type: time
+	    exitTime: any;
 	
 	    static createFrom(source: any = {}) {
 	        return new CommandResult(source);
@@ -20,7 +154,165 @@ export namespace execute {
 	        this.stderr = source["stderr"];
 	        this.error = source["error"];
 	        this.aborted = source["aborted"];
+	        this.status = source["status"];
+	        this.events = this.convertValues(source["events"], Event);
+	        this.peak = this.convertValues(source["peak"], Usage);
+	        this.match = this.convertValues(source["match"], OutputMatch);
+	        this.stdoutLog = source["stdoutLog"];
+	        this.stderrLog = source["stderrLog"];
+	        this.installerLog = source["installerLog"];
+	        this.exitTime = this.convertValues(source["exitTime"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace installer {
+	
+	export class Analysis {
+	    type: string;
+	    kind: string;
+	    flags: string[];
+	    exitCodes: Record<number, string>;
+	    evidence: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Analysis(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.kind = source["kind"];
+	        this.flags = source["flags"];
+	        this.exitCodes = source["exitCodes"];
+	        this.evidence = source["evidence"];
+	    }
+	}
+	export class Signature {
+	    signed: boolean;
+	    subject: string;
+	    commonName: string;
+	    organization: string[];
+	    issuer: string;
+	    // Go type: time
+	    timestamp: any;
+	    digestAlgorithm: string;
+	    digest: string;
+	    valid: boolean;
+	    trusted: boolean;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Signature(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.signed = source["signed"];
+	        this.subject = source["subject"];
+	        this.commonName = source["commonName"];
+	        this.organization = source["organization"];
+	        this.issuer = source["issuer"];
+	        this.timestamp = this.convertValues(source["timestamp"], null);
+	        this.digestAlgorithm = source["digestAlgorithm"];
+	        this.digest = source["digest"];
+	        this.valid = source["valid"];
+	        this.trusted = source["trusted"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class InfPackage {
+	    path: string;
+	    class: string;
+	    provider: string;
+	    date: string;
+	    version: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new InfPackage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.class = source["class"];
+	        this.provider = source["provider"];
+	        this.date = source["date"];
+	        this.version = source["version"];
+	    }
+	}
+	export class InfMatch {
+	    package: InfPackage;
+	    description: string;
+	    hardwareId: string;
+	    rank: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new InfMatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.package = this.convertValues(source["package"], InfPackage);
+	        this.description = source["description"];
+	        this.hardwareId = source["hardwareId"];
+	        this.rank = source["rank"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 
 }
@@ -105,6 +397,46 @@ export namespace porter {
 
 }
 
+export namespace power {
+	
+	export class Countdown {
+	    action: storage.SuccessAction;
+	    // Go type: time
+	    deadline: any;
+	    remaining: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Countdown(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.action = source["action"];
+	        this.deadline = this.convertValues(source["deadline"], null);
+	        this.remaining = source["remaining"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
 export namespace status {
 	
 	export enum Status {
@@ -117,6 +449,25 @@ export namespace status {
 	    SKIPED = "skiped",
 	    SPEEDED = "speeded",
 	    ERRORED = "errored",
+	    REBOOT_REQUIRED = "reboot_required",
+	}
+	export class Summary {
+	    counts: {[key: string]: number};
+	    total: number;
+	    succeeded: boolean;
+	    rebootRequired: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Summary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.counts = source["counts"];
+	        this.total = source["total"];
+	        this.succeeded = source["succeeded"];
+	        this.rebootRequired = source["rebootRequired"];
+	    }
 	}
 
 }
@@ -133,12 +484,14 @@ export namespace storage {
 	    REBOOT = "reboot",
 	    SHUTDOWN = "shutdown",
 	    FIRMWARE = "firmware",
+	    REBOOT_IF_REQUIRED = "reboot_if_required",
 	}
 	export class AppSetting {
 	    create_partition: boolean;
 	    set_password: boolean;
 	    password: string;
-	    parallel_install: boolean;
+	    max_concurrency: number;
+	    resource_limits: {[key: string]: number};
 	    success_action: SuccessAction;
 	    success_action_delay: number;
 	    filter_miniport_nic: boolean;
@@ -156,7 +509,8 @@ export namespace storage {
 	        this.create_partition = source["create_partition"];
 	        this.set_password = source["set_password"];
 	        this.password = source["password"];
-	        this.parallel_install = source["parallel_install"];
+	        this.max_concurrency = source["max_concurrency"];
+	        this.resource_limits = source["resource_limits"];
 	        this.success_action = source["success_action"];
 	        this.success_action_delay = source["success_action_delay"];
 	        this.filter_miniport_nic = source["filter_miniport_nic"];
@@ -170,11 +524,22 @@ export namespace storage {
 	    id: string;
 	    name: string;
 	    type: DriverType;
+	    kind: string;
 	    path: string;
 	    flags: string[];
+	    script: string;
+	    interpreter: string;
+	    entry: string;
 	    minExeTime: number;
 	    allowRtCodes: number[];
 	    incompatibles: string[];
+	    env: string[];
+	    workDir: string;
+	    idleTimeout: number;
+	    encoding: string;
+	    exitCodes: Record<number, string>;
+	    outputRules: OutputRule[];
+	    metadata: DriverMetadata;
 	
 	    static createFrom(source: any = {}) {
 	        return new Driver(source);
@@ -185,29 +550,63 @@ export namespace storage {
 	        this.id = source["id"];
 	        this.name = source["name"];
 	        this.type = source["type"];
+	        this.kind = source["kind"];
 	        this.path = source["path"];
 	        this.flags = source["flags"];
+	        this.script = source["script"];
+	        this.interpreter = source["interpreter"];
+	        this.entry = source["entry"];
 	        this.minExeTime = source["minExeTime"];
 	        this.allowRtCodes = source["allowRtCodes"];
 	        this.incompatibles = source["incompatibles"];
+	        this.env = source["env"];
+	        this.workDir = source["workDir"];
+	        this.idleTimeout = source["idleTimeout"];
+	        this.encoding = source["encoding"];
+	        this.exitCodes = source["exitCodes"];
+	        this.outputRules = this.convertValues(source["outputRules"], OutputRule);
+	        this.metadata = this.convertValues(source["metadata"], DriverMetadata);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class DriverGroup {
-	    id: string;
-	    name: string;
-	    type: DriverType;
-	    drivers: Driver[];
+	export class DriverMetadata {
+	    productName: string;
+	    companyName: string;
+	    fileVersion: string;
+	    productVersion: string;
+	    // Go type: time
+	    modTime: any;
+	    size: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new DriverGroup(source);
+	        return new DriverMetadata(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.name = source["name"];
-	        this.type = source["type"];
-	        this.drivers = this.convertValues(source["drivers"], Driver);
+	        this.productName = source["productName"];
+	        this.companyName = source["companyName"];
+	        this.fileVersion = source["fileVersion"];
+	        this.productVersion = source["productVersion"];
+	        this.modTime = this.convertValues(source["modTime"], null);
+	        this.size = source["size"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -228,13 +627,111 @@ export namespace storage {
 		    return a;
 		}
 	}
-
-}
-
-export namespace sysinfo {
-	
-	export class Win32_BaseBoard {
-	    Caption: string;
+	export class DriverGroup {
+	    id: string;
+	    name: string;
+	    type: DriverType;
+	    drivers: Driver[];
+	    resourceClass: string;
+	    signaturePolicy: SignaturePolicy;
+	
+	    static createFrom(source: any = {}) {
+	        return new DriverGroup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.drivers = this.convertValues(source["drivers"], Driver);
+	        this.resourceClass = source["resourceClass"];
+	        this.signaturePolicy = this.convertValues(source["signaturePolicy"], SignaturePolicy);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class OutputRule {
+	    pattern: string;
+	    source: string;
+	    outcome: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new OutputRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.pattern = source["pattern"];
+	        this.source = source["source"];
+	        this.outcome = source["outcome"];
+	    }
+	}
+	export class SignaturePolicy {
+	    action: string;
+	    signers: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SignaturePolicy(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.action = source["action"];
+	        this.signers = source["signers"];
+	    }
+	}
+	export class Profile {
+	    id: string;
+	    name: string;
+	    groups: string[];
+	    create_partition: boolean;
+	    set_password: boolean;
+	    password: string;
+	    max_concurrency?: number;
+	    success_action?: SuccessAction;
+	    success_action_delay?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Profile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.groups = source["groups"];
+	        this.create_partition = source["create_partition"];
+	        this.set_password = source["set_password"];
+	        this.password = source["password"];
+	        this.max_concurrency = source["max_concurrency"];
+	        this.success_action = source["success_action"];
+	        this.success_action_delay = source["success_action_delay"];
+	    }
+	}
+
+}
+
+export namespace sysinfo {
+	
+	export class Win32_BaseBoard {
+	    Caption: string;
 	    ConfigOptions: string[];
 	    CreationClassName: string;
 	    Depth: number;
@@ -1122,6 +1619,812 @@ export namespace sysinfo {
 		    return a;
 		}
 	}
+	export class DeviceMatch {
+	    deviceId: string;
+	    name: string;
+	    matches: installer.InfMatch[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DeviceMatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deviceId = source["deviceId"];
+	        this.name = source["name"];
+	        this.matches = this.convertValues(source["matches"], installer.InfMatch);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DriverMatchReport {
+	    matched: DeviceMatch[];
+	    unmatched: DeviceMatch[];
+	    errors: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DriverMatchReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.matched = this.convertValues(source["matched"], DeviceMatch);
+	        this.unmatched = this.convertValues(source["unmatched"], DeviceMatch);
+	        this.errors = source["errors"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Win32_PnPEntity {
+	    Availability: number;
+	    Caption: string;
+	    ClassGuid: string;
+	    CompatibleID: string[];
+	    ConfigManagerErrorCode: number;
+	    ConfigManagerUserConfig: boolean;
+	    CreationClassName: string;
+	    Description: string;
+	    DeviceID: string;
+	    ErrorCleared: boolean;
+	    ErrorDescription: string;
+	    HardwareID: string[];
+	    // Go type: time
+	    InstallDate: any;
+	    LastErrorCode: number;
+	    Manufacturer: string;
+	    Name: string;
+	    PNPClass: string;
+	    PNPDeviceID: string;
+	    PowerManagementCapabilities: number[];
+	    PowerManagementSupported: boolean;
+	    Present: boolean;
+	    Service: string;
+	    Status: string;
+	    StatusInfo: number;
+	    SystemCreationClassName: string;
+	    SystemName: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Win32_PnPEntity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Availability = source["Availability"];
+	        this.Caption = source["Caption"];
+	        this.ClassGuid = source["ClassGuid"];
+	        this.CompatibleID = source["CompatibleID"];
+	        this.ConfigManagerErrorCode = source["ConfigManagerErrorCode"];
+	        this.ConfigManagerUserConfig = source["ConfigManagerUserConfig"];
+	        this.CreationClassName = source["CreationClassName"];
+	        this.Description = source["Description"];
+	        this.DeviceID = source["DeviceID"];
+	        this.ErrorCleared = source["ErrorCleared"];
+	        this.ErrorDescription = source["ErrorDescription"];
+	        this.HardwareID = source["HardwareID"];
+	        this.InstallDate = this.convertValues(source["InstallDate"], null);
+	        this.LastErrorCode = source["LastErrorCode"];
+	        this.Manufacturer = source["Manufacturer"];
+	        this.Name = source["Name"];
+	        this.PNPClass = source["PNPClass"];
+	        this.PNPDeviceID = source["PNPDeviceID"];
+	        this.PowerManagementCapabilities = source["PowerManagementCapabilities"];
+	        this.PowerManagementSupported = source["PowerManagementSupported"];
+	        this.Present = source["Present"];
+	        this.Service = source["Service"];
+	        this.Status = source["Status"];
+	        this.StatusInfo = source["StatusInfo"];
+	        this.SystemCreationClassName = source["SystemCreationClassName"];
+	        this.SystemName = source["SystemName"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProblemDevice {
+	    deviceId: string;
+	    name: string;
+	    classGuid: string;
+	    hardwareIds: string[];
+	    status: string;
+	    errorCode: number;
+	    explanation: string;
+	    missingDriver: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProblemDevice(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deviceId = source["deviceId"];
+	        this.name = source["name"];
+	        this.classGuid = source["classGuid"];
+	        this.hardwareIds = source["hardwareIds"];
+	        this.status = source["status"];
+	        this.errorCode = source["errorCode"];
+	        this.explanation = source["explanation"];
+	        this.missingDriver = source["missingDriver"];
+	    }
+	}
+	export class DriverComparison {
+	    deviceId: string;
+	    deviceName: string;
+	    productName: string;
+	    groupId: string;
+	    driverId: string;
+	    driverName: string;
+	    installedVersion: string;
+	    // Go type: time
+	    installedDate: any;
+	    installedInf: string;
+	    provider: string;
+	    packageVersion: string;
+	    packageDate: string;
+	    state: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DriverComparison(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deviceId = source["deviceId"];
+	        this.deviceName = source["deviceName"];
+	        this.productName = source["productName"];
+	        this.groupId = source["groupId"];
+	        this.driverId = source["driverId"];
+	        this.driverName = source["driverName"];
+	        this.installedVersion = source["installedVersion"];
+	        this.installedDate = this.convertValues(source["installedDate"], null);
+	        this.installedInf = source["installedInf"];
+	        this.provider = source["provider"];
+	        this.packageVersion = source["packageVersion"];
+	        this.packageDate = source["packageDate"];
+	        this.state = source["state"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Win32_PnPSignedDriver {
+	    Caption: string;
+	    ClassGuid: string;
+	    CompatID: string;
+	    CreationClassName: string;
+	    Description: string;
+	    DeviceClass: string;
+	    DeviceID: string;
+	    DeviceName: string;
+	    DevLoader: string;
+	    // Go type: time
+	    DriverDate: any;
+	    DriverName: string;
+	    DriverProviderName: string;
+	    DriverVersion: string;
+	    FriendlyName: string;
+	    HardWareID: string;
+	    InfName: string;
+	    // Go type: time
+	    InstallDate: any;
+	    IsSigned: boolean;
+	    Location: string;
+	    Manufacturer: string;
+	    Name: string;
+	    PDO: string;
+	    Signer: string;
+	    Started: boolean;
+	    StartMode: string;
+	    Status: string;
+	    SystemCreationClassName: string;
+	    SystemName: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Win32_PnPSignedDriver(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Caption = source["Caption"];
+	        this.ClassGuid = source["ClassGuid"];
+	        this.CompatID = source["CompatID"];
+	        this.CreationClassName = source["CreationClassName"];
+	        this.Description = source["Description"];
+	        this.DeviceClass = source["DeviceClass"];
+	        this.DeviceID = source["DeviceID"];
+	        this.DeviceName = source["DeviceName"];
+	        this.DevLoader = source["DevLoader"];
+	        this.DriverDate = this.convertValues(source["DriverDate"], null);
+	        this.DriverName = source["DriverName"];
+	        this.DriverProviderName = source["DriverProviderName"];
+	        this.DriverVersion = source["DriverVersion"];
+	        this.FriendlyName = source["FriendlyName"];
+	        this.HardWareID = source["HardWareID"];
+	        this.InfName = source["InfName"];
+	        this.InstallDate = this.convertValues(source["InstallDate"], null);
+	        this.IsSigned = source["IsSigned"];
+	        this.Location = source["Location"];
+	        this.Manufacturer = source["Manufacturer"];
+	        this.Name = source["Name"];
+	        this.PDO = source["PDO"];
+	        this.Signer = source["Signer"];
+	        this.Started = source["Started"];
+	        this.StartMode = source["StartMode"];
+	        this.Status = source["Status"];
+	        this.SystemCreationClassName = source["SystemCreationClassName"];
+	        this.SystemName = source["SystemName"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Battery {
+	    name: string;
+	    deviceId: string;
+	    chemistry: string;
+	    status: string;
+	    chargeRemaining: number;
+	    designCapacity: number;
+	    fullChargeCapacity: number;
+	    health: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Battery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.deviceId = source["deviceId"];
+	        this.chemistry = source["chemistry"];
+	        this.status = source["status"];
+	        this.chargeRemaining = source["chargeRemaining"];
+	        this.designCapacity = source["designCapacity"];
+	        this.fullChargeCapacity = source["fullChargeCapacity"];
+	        this.health = source["health"];
+	    }
+	}
+	export class Bios {
+	    manufacturer: string;
+	    version: string;
+	    // Go type: time
+	    releaseDate: any;
+	    serialNumber: string;
+	    smbiosVersion: string;
+	    controllerVersion: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Bios(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.manufacturer = source["manufacturer"];
+	        this.version = source["version"];
+	        this.releaseDate = this.convertValues(source["releaseDate"], null);
+	        this.serialNumber = source["serialNumber"];
+	        this.smbiosVersion = source["smbiosVersion"];
+	        this.controllerVersion = source["controllerVersion"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Monitor {
+	    name: string;
+	    manufacturer: string;
+	    productCode: string;
+	    serialNumber: string;
+	    year: number;
+	    week: number;
+	    active: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Monitor(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.manufacturer = source["manufacturer"];
+	        this.productCode = source["productCode"];
+	        this.serialNumber = source["serialNumber"];
+	        this.year = source["year"];
+	        this.week = source["week"];
+	        this.active = source["active"];
+	    }
+	}
+	export class OperatingSystem {
+	    name: string;
+	    edition: string;
+	    version: string;
+	    build: string;
+	    architecture: string;
+	    productType: string;
+	    sku: number;
+	    productId: string;
+	    registeredUser: string;
+	    organization: string;
+	    locale: string;
+	    // Go type: time
+	    installDate: any;
+	    // Go type: time
+	    lastBootUpTime: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new OperatingSystem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.edition = source["edition"];
+	        this.version = source["version"];
+	        this.build = source["build"];
+	        this.architecture = source["architecture"];
+	        this.productType = source["productType"];
+	        this.sku = source["sku"];
+	        this.productId = source["productId"];
+	        this.registeredUser = source["registeredUser"];
+	        this.organization = source["organization"];
+	        this.locale = source["locale"];
+	        this.installDate = this.convertValues(source["installDate"], null);
+	        this.lastBootUpTime = this.convertValues(source["lastBootUpTime"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SoundDevice {
+	    name: string;
+	    manufacturer: string;
+	    deviceId: string;
+	    status: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SoundDevice(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.manufacturer = source["manufacturer"];
+	        this.deviceId = source["deviceId"];
+	        this.status = source["status"];
+	    }
+	}
+	export class CpuSummary {
+	    name: string;
+	    cores: number;
+	    threads: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CpuSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.cores = source["cores"];
+	        this.threads = source["threads"];
+	    }
+	}
+	export class DiskSummary {
+	    model: string;
+	    size: number;
+	    sizeText: string;
+	    media: string;
+	    bus: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiskSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.model = source["model"];
+	        this.size = source["size"];
+	        this.sizeText = source["sizeText"];
+	        this.media = source["media"];
+	        this.bus = source["bus"];
+	    }
+	}
+	export class GpuSummary {
+	    name: string;
+	    model: string;
+	    memory: number;
+	    memoryText: string;
+	    driverVersion: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GpuSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.model = source["model"];
+	        this.memory = source["memory"];
+	        this.memoryText = source["memoryText"];
+	        this.driverVersion = source["driverVersion"];
+	    }
+	}
+	export class MSFT_PhysicalDisk {
+	    AllocatedSize: number;
+	    BusType: number;
+	    CanPool: boolean;
+	    Description: string;
+	    DeviceId: string;
+	    EnclosureNumber: number;
+	    FirmwareVersion: string;
+	    FriendlyName: string;
+	    HealthStatus: number;
+	    IsIndicationEnabled: boolean;
+	    IsPartial: boolean;
+	    LogicalSectorSize: number;
+	    MediaType: number;
+	    Model: string;
+	    ObjectId: string;
+	    PhysicalLocation: string;
+	    PhysicalSectorSize: number;
+	    SerialNumber: string;
+	    Size: number;
+	    SlotNumber: number;
+	    SoftwareVersion: string;
+	    SpindleSpeed: number;
+	    UniqueId: string;
+	    Usage: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MSFT_PhysicalDisk(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.AllocatedSize = source["AllocatedSize"];
+	        this.BusType = source["BusType"];
+	        this.CanPool = source["CanPool"];
+	        this.Description = source["Description"];
+	        this.DeviceId = source["DeviceId"];
+	        this.EnclosureNumber = source["EnclosureNumber"];
+	        this.FirmwareVersion = source["FirmwareVersion"];
+	        this.FriendlyName = source["FriendlyName"];
+	        this.HealthStatus = source["HealthStatus"];
+	        this.IsIndicationEnabled = source["IsIndicationEnabled"];
+	        this.IsPartial = source["IsPartial"];
+	        this.LogicalSectorSize = source["LogicalSectorSize"];
+	        this.MediaType = source["MediaType"];
+	        this.Model = source["Model"];
+	        this.ObjectId = source["ObjectId"];
+	        this.PhysicalLocation = source["PhysicalLocation"];
+	        this.PhysicalSectorSize = source["PhysicalSectorSize"];
+	        this.SerialNumber = source["SerialNumber"];
+	        this.Size = source["Size"];
+	        this.SlotNumber = source["SlotNumber"];
+	        this.SoftwareVersion = source["SoftwareVersion"];
+	        this.SpindleSpeed = source["SpindleSpeed"];
+	        this.UniqueId = source["UniqueId"];
+	        this.Usage = source["Usage"];
+	    }
+	}
+	export class MemoryModule {
+	    slot: string;
+	    manufacturer: string;
+	    partNumber: string;
+	    capacity: number;
+	    capacityText: string;
+	    type: string;
+	    formFactor: string;
+	    speed: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MemoryModule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.slot = source["slot"];
+	        this.manufacturer = source["manufacturer"];
+	        this.partNumber = source["partNumber"];
+	        this.capacity = source["capacity"];
+	        this.capacityText = source["capacityText"];
+	        this.type = source["type"];
+	        this.formFactor = source["formFactor"];
+	        this.speed = source["speed"];
+	    }
+	}
+	export class MemorySummary {
+	    total: number;
+	    totalText: string;
+	    modules: MemoryModule[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MemorySummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.total = source["total"];
+	        this.totalText = source["totalText"];
+	        this.modules = this.convertValues(source["modules"], MemoryModule);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NicSummary {
+	    name: string;
+	    mac: string;
+	    speed: number;
+	    speedText: string;
+	    connected: boolean;
+	    physical: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new NicSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.mac = source["mac"];
+	        this.speed = source["speed"];
+	        this.speedText = source["speedText"];
+	        this.connected = source["connected"];
+	        this.physical = source["physical"];
+	    }
+	}
+	export class Summary {
+	    motherboards: string[];
+	    cpus: CpuSummary[];
+	    memory: MemorySummary;
+	    gpus: GpuSummary[];
+	    nics: NicSummary[];
+	    disks: DiskSummary[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Summary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.motherboards = source["motherboards"];
+	        this.cpus = this.convertValues(source["cpus"], CpuSummary);
+	        this.memory = this.convertValues(source["memory"], MemorySummary);
+	        this.gpus = this.convertValues(source["gpus"], GpuSummary);
+	        this.nics = this.convertValues(source["nics"], NicSummary);
+	        this.disks = this.convertValues(source["disks"], DiskSummary);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DeviceName {
+	    deviceId: string;
+	    name: string;
+	    vendor: string;
+	    device: string;
+	    subsystem: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeviceName(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deviceId = source["deviceId"];
+	        this.name = source["name"];
+	        this.vendor = source["vendor"];
+	        this.device = source["device"];
+	        this.subsystem = source["subsystem"];
+	    }
+	}
+	export class Snapshot {
+	    summary: Summary;
+	    errors: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new Snapshot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.summary = this.convertValues(source["summary"], Summary);
+	        this.errors = source["errors"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class InstalledProduct {
+	    name: string;
+	    version: string;
+	    publisher: string;
+	    // Go type: time
+	    installDate: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new InstalledProduct(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.version = source["version"];
+	        this.publisher = source["publisher"];
+	        this.installDate = this.convertValues(source["installDate"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 
 }
 
@@ -2,6 +2,10 @@
 // This file is automatically generated. DO NOT EDIT
 import {storage} from '../models';
 
+export function Override(arg1:storage.AppSetting):Promise<void>;
+
 export function Read():Promise<storage.AppSetting>;
 
+export function Restore():Promise<void>;
+
 export function Update(arg1:storage.AppSetting):Promise<void>;
@@ -2,10 +2,18 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function Override(arg1) {
+  return window['go']['storage']['AppSettingManager']['Override'](arg1);
+}
+
 export function Read() {
   return window['go']['storage']['AppSettingManager']['Read']();
 }
 
+export function Restore() {
+  return window['go']['storage']['AppSettingManager']['Restore']();
+}
+
 export function Update(arg1) {
   return window['go']['storage']['AppSettingManager']['Update'](arg1);
 }
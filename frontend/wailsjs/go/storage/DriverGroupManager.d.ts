@@ -14,6 +14,8 @@ export function MoveBehind(arg1:string,arg2:number):Promise<Array<storage.Driver
 
 export function Read():Promise<Array<storage.DriverGroup>>;
 
+export function RefreshMetadata():Promise<Array<storage.DriverGroup>>;
+
 export function Remove(arg1:string):Promise<void>;
 
 export function Update(arg1:storage.DriverGroup):Promise<void>;
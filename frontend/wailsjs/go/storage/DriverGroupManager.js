@@ -26,6 +26,10 @@ export function Read() {
   return window['go']['storage']['DriverGroupManager']['Read']();
 }
 
+export function RefreshMetadata() {
+  return window['go']['storage']['DriverGroupManager']['RefreshMetadata']();
+}
+
 export function Remove(arg1) {
   return window['go']['storage']['DriverGroupManager']['Remove'](arg1);
 }
@@ -2,18 +2,50 @@
 // This file is automatically generated. DO NOT EDIT
 import {sysinfo} from '../models';
 
+export function BatteryInfo():Promise<Array<sysinfo.Battery>>;
+
+export function BiosInfo():Promise<Array<sysinfo.Bios>>;
+
+export function CompareDrivers():Promise<Array<sysinfo.DriverComparison>>;
+
 export function CpuInfo():Promise<Array<sysinfo.Win32_Processor>>;
 
+export function DeviceNames():Promise<Array<sysinfo.DeviceName>>;
+
 export function DiskInfo():Promise<Array<sysinfo.Win32_DiskDrive>>;
 
 export function DiskParitionInfo():Promise<Array<sysinfo.Win32_DiskPartition>>;
 
 export function GpuInfo():Promise<Array<sysinfo.Win32_VideoController>>;
 
+export function InstalledProducts():Promise<Array<sysinfo.InstalledProduct>>;
+
+export function MatchDrivers():Promise<sysinfo.DriverMatchReport>;
+
 export function MemoryInfo():Promise<Array<sysinfo.Win32_PhysicalMemory>>;
 
+export function MonitorInfo():Promise<Array<sysinfo.Monitor>>;
+
 export function MotherboardInfo():Promise<Array<sysinfo.Win32_BaseBoard>>;
 
 export function NicInfo():Promise<Array<sysinfo.Win32_NetworkAdapter>>;
 
+export function OsInfo():Promise<Array<sysinfo.OperatingSystem>>;
+
+export function PhysicalDiskInfo():Promise<Array<sysinfo.MSFT_PhysicalDisk>>;
+
+export function PnpEntityInfo():Promise<Array<sysinfo.Win32_PnPEntity>>;
+
+export function ProblemDevices():Promise<Array<sysinfo.ProblemDevice>>;
+
+export function RefreshSummary():Promise<sysinfo.Snapshot>;
+
+export function ResolveDeviceName(arg1:string):Promise<sysinfo.DeviceName>;
+
+export function SignedDriverInfo():Promise<Array<sysinfo.Win32_PnPSignedDriver>>;
+
+export function SoundInfo():Promise<Array<sysinfo.SoundDevice>>;
+
+export function Summary():Promise<sysinfo.Snapshot>;
+
 export function UserAccountInfo():Promise<Array<sysinfo.Win32_UserAccount>>;
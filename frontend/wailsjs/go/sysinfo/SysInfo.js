@@ -2,10 +2,26 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function BatteryInfo() {
+  return window['go']['sysinfo']['SysInfo']['BatteryInfo']();
+}
+
+export function BiosInfo() {
+  return window['go']['sysinfo']['SysInfo']['BiosInfo']();
+}
+
+export function CompareDrivers() {
+  return window['go']['sysinfo']['SysInfo']['CompareDrivers']();
+}
+
 export function CpuInfo() {
   return window['go']['sysinfo']['SysInfo']['CpuInfo']();
 }
 
+export function DeviceNames() {
+  return window['go']['sysinfo']['SysInfo']['DeviceNames']();
+}
+
 export function DiskInfo() {
   return window['go']['sysinfo']['SysInfo']['DiskInfo']();
 }
@@ -18,10 +34,22 @@ export function GpuInfo() {
   return window['go']['sysinfo']['SysInfo']['GpuInfo']();
 }
 
+export function InstalledProducts() {
+  return window['go']['sysinfo']['SysInfo']['InstalledProducts']();
+}
+
+export function MatchDrivers() {
+  return window['go']['sysinfo']['SysInfo']['MatchDrivers']();
+}
+
 export function MemoryInfo() {
   return window['go']['sysinfo']['SysInfo']['MemoryInfo']();
 }
 
+export function MonitorInfo() {
+  return window['go']['sysinfo']['SysInfo']['MonitorInfo']();
+}
+
 export function MotherboardInfo() {
   return window['go']['sysinfo']['SysInfo']['MotherboardInfo']();
 }
@@ -30,6 +58,42 @@ export function NicInfo() {
   return window['go']['sysinfo']['SysInfo']['NicInfo']();
 }
 
+export function OsInfo() {
+  return window['go']['sysinfo']['SysInfo']['OsInfo']();
+}
+
+export function PhysicalDiskInfo() {
+  return window['go']['sysinfo']['SysInfo']['PhysicalDiskInfo']();
+}
+
+export function PnpEntityInfo() {
+  return window['go']['sysinfo']['SysInfo']['PnpEntityInfo']();
+}
+
+export function ProblemDevices() {
+  return window['go']['sysinfo']['SysInfo']['ProblemDevices']();
+}
+
+export function RefreshSummary() {
+  return window['go']['sysinfo']['SysInfo']['RefreshSummary']();
+}
+
+export function ResolveDeviceName(arg1) {
+  return window['go']['sysinfo']['SysInfo']['ResolveDeviceName'](arg1);
+}
+
+export function SignedDriverInfo() {
+  return window['go']['sysinfo']['SysInfo']['SignedDriverInfo']();
+}
+
+export function SoundInfo() {
+  return window['go']['sysinfo']['SysInfo']['SoundInfo']();
+}
+
+export function Summary() {
+  return window['go']['sysinfo']['SysInfo']['Summary']();
+}
+
 export function UserAccountInfo() {
   return window['go']['sysinfo']['SysInfo']['UserAccountInfo']();
 }
@@ -3,11 +3,15 @@ package main
 import (
 	"context"
 	"driver-box/pkg/execute"
+	"driver-box/pkg/installer"
 	"driver-box/pkg/porter"
+	"driver-box/pkg/power"
 	"driver-box/pkg/status"
 	"driver-box/pkg/storage"
 	"driver-box/pkg/sysinfo"
 	"embed"
+	"flag"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -16,6 +20,7 @@ import (
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
 	"github.com/wailsapp/wails/v2/pkg/options/windows"
+	wails_runtime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 //go:embed all:frontend/dist
@@ -33,6 +38,8 @@ var (
 	buildVersion string
 	// Version struct, parsed from [buildVersion]
 	version *semver.Version
+	// Name or ID of the installation profile to be applied on startup
+	startupProfile string
 )
 
 func init() {
@@ -44,6 +51,11 @@ func init() {
 		version = v
 	}
 
+	args := flag.NewFlagSet(filepath.Base(os.Args[0]), flag.ContinueOnError)
+	args.SetOutput(io.Discard)
+	args.StringVar(&startupProfile, "profile", "", "name or ID of the installation profile to apply")
+	args.Parse(os.Args[1:])
+
 	if pathExe, err := os.Executable(); err != nil {
 		panic(err)
 	} else {
@@ -77,7 +89,29 @@ func init() {
 
 func main() {
 	app := &App{}
-	mgt := &execute.CommandExecutor{}
+	powerMgt := &power.Manager{}
+	groupMgt := &storage.DriverGroupManager{Path: filepath.Join(dirConf, "groups.json")}
+	settingMgt := &storage.AppSettingManager{Path: filepath.Join(dirConf, "setting.json")}
+	mgt := &execute.CommandExecutor{
+		Groups:   groupMgt,
+		Settings: settingMgt,
+		Placeholders: execute.Placeholders{
+			"ROOT":    dirRoot,
+			"DRIVERS": dirDir,
+			"CONF":    dirConf,
+			"TEMP":    os.TempDir(),
+		},
+		LogDir:   filepath.Join(dirRoot, "logs"),
+		CacheDir: filepath.Join(dirRoot, "cache"),
+	}
+	profileMgt := &storage.ProfileManager{
+		Path:     filepath.Join(dirConf, "profiles.json"),
+		Groups:   groupMgt,
+		Settings: settingMgt,
+		Startup:  startupProfile,
+	}
+	metadata := installer.MetadataReader{Expand: mgt.Placeholders.Expand, Root: dirRoot}
+	groupMgt.Metadata = metadata
 
 	err := wails.Run(&options.App{
 		Title:     "driver-box",
@@ -99,14 +133,28 @@ func main() {
 
 			app.SetContext(ctx)
 			mgt.SetContext(ctx)
+			powerMgt.SetContext(ctx)
+
+			if _, err := profileMgt.StartupProfile(); err != nil {
+				wails_runtime.LogErrorf(ctx, "failed to apply the profile %s: %v", startupProfile, err)
+			}
 		},
 		Bind: []interface{}{
 			app,
 			mgt,
-			&storage.DriverGroupManager{Path: filepath.Join(dirConf, "groups.json")},
-			&storage.AppSettingManager{Path: filepath.Join(dirConf, "setting.json")},
+			powerMgt,
+			groupMgt,
+			settingMgt,
+			profileMgt,
 			&porter.Porter{DirRoot: dirRoot, Message: make(chan string, 512), Targets: []string{dirConf, dirDir}},
-			&sysinfo.SysInfo{},
+			&sysinfo.SysInfo{
+				DriverDir: dirDir,
+				IDDir:     dirConf,
+				Groups:    groupMgt,
+				Settings:  settingMgt,
+				Collector: &sysinfo.Collector{},
+				Resolve:   metadata.Resolve,
+			},
 		},
 		EnumBind: []interface{}{
 			[]struct {
@@ -125,6 +173,7 @@ func main() {
 				{storage.Reboot, "REBOOT"},
 				{storage.Shutdown, "SHUTDOWN"},
 				{storage.Firmware, "FIRMWARE"},
+				{storage.RebootIfRequired, "REBOOT_IF_REQUIRED"},
 			},
 			[]struct {
 				Value  status.Status
@@ -139,6 +188,7 @@ func main() {
 				{status.Skiped, "SKIPED"},
 				{status.Speeded, "SPEEDED"},
 				{status.Errored, "ERRORED"},
+				{status.RebootRequired, "REBOOT_REQUIRED"},
 			},
 		},
 		Windows: &windows.Options{
@@ -0,0 +1,168 @@
+package execute
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"driver-box/pkg/porter"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const defaultCacheRetention = 7 * 24 * time.Hour
+
+// Extracts the archive at path and returns the directory of its content.
+// Extractions are cached in [CommandExecutor.CacheDir] by the checksum of the archive. Without a cache directory,
+// the archive is extracted into a temporary directory, which is also returned as temp to be removed after the run.
+func (ce *CommandExecutor) extractArchive(path string) (dir string, temp string, err error) {
+	if ce.CacheDir == "" {
+		if dir, err = os.MkdirTemp("", "driver-box-*"); err != nil {
+			return "", "", err
+		}
+		if err := unzip(path, dir); err != nil {
+			os.RemoveAll(dir)
+			return "", "", err
+		}
+		return dir, dir, nil
+	}
+
+	sum, err := checksum(path)
+	if err != nil {
+		return "", "", err
+	}
+
+	// extractions in progress and their partial directories are kept from being pruned
+	ce.cacheMu.RLock()
+	defer ce.cacheMu.RUnlock()
+
+	dir = filepath.Join(ce.CacheDir, sum)
+	if _, err := os.Stat(dir); err == nil {
+		// marks the extraction as recently used for pruning
+		now := time.Now()
+		os.Chtimes(dir, now, now)
+		return dir, "", nil
+	}
+
+	if err := os.MkdirAll(ce.CacheDir, os.ModePerm); err != nil {
+		return "", "", err
+	}
+
+	// extracted aside and renamed afterwards, so that an incomplete extraction is never used
+	partial, err := os.MkdirTemp(ce.CacheDir, sum+".partial-*")
+	if err != nil {
+		return "", "", err
+	}
+	if err := unzip(path, partial); err != nil {
+		os.RemoveAll(partial)
+		return "", "", err
+	}
+
+	if err := os.Rename(partial, dir); err != nil {
+		os.RemoveAll(partial)
+		// the same archive may have been extracted concurrently
+		if _, statErr := os.Stat(dir); statErr != nil {
+			return "", "", err
+		}
+	}
+	return dir, "", nil
+}
+
+// Removes cached extractions last used before the retention period, and leftovers of interrupted extractions.
+// Pruning waits for the extractions in progress, and those started meanwhile wait for the pruning to finish.
+func (ce *CommandExecutor) pruneCache() error {
+	retention := ce.CacheRetention
+	if retention <= 0 {
+		retention = defaultCacheRetention
+	}
+
+	ce.cacheMu.Lock()
+	defer ce.cacheMu.Unlock()
+
+	entries, err := os.ReadDir(ce.CacheDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+
+		if strings.Contains(entry.Name(), ".partial-") || time.Since(info.ModTime()) > retention {
+			if err := os.RemoveAll(filepath.Join(ce.CacheDir, entry.Name())); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Returns the hex encoded SHA-256 checksum of a file.
+func checksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Extracts a ZIP archive into dest.
+func unzip(orig string, dest string) error {
+	zreader, err := zip.OpenReader(orig)
+	if err != nil {
+		return err
+	}
+	defer zreader.Close()
+
+	extract := func(zf *zip.File) error {
+		path, err := porter.SafeJoin(dest, zf.Name)
+		if err != nil {
+			return err
+		}
+
+		if zf.FileInfo().IsDir() {
+			return os.MkdirAll(path, os.ModePerm)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			return err
+		}
+
+		zfreader, err := zf.Open()
+		if err != nil {
+			return err
+		}
+		defer zfreader.Close()
+
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, zf.Mode()|0200)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(file, zfreader)
+		return err
+	}
+
+	for _, zf := range zreader.File {
+		if err := extract(zf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package execute
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Writes a ZIP archive with the given files, keyed by their names, into dir.
+func writeZip(t *testing.T, dir string, files map[string]string) string {
+	t.Helper()
+
+	file, err := os.CreateTemp(dir, "driver-*.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	w := zip.NewWriter(file)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return file.Name()
+}
+
+// Returns the names of the entries in dir.
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestExtractArchive(t *testing.T) {
+	archive := writeZip(t, t.TempDir(), map[string]string{"setup/setup.exe": "installer", "readme.txt": "readme"})
+
+	check := func(t *testing.T, dir string) {
+		t.Helper()
+		if content, err := os.ReadFile(filepath.Join(dir, "setup", "setup.exe")); err != nil || string(content) != "installer" {
+			t.Errorf("extracted entry = %q, %v", content, err)
+		}
+	}
+
+	t.Run("temporary", func(t *testing.T) {
+		ce := &CommandExecutor{}
+		dir, temp, err := ce.extractArchive(archive)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(temp)
+
+		if temp != dir {
+			t.Errorf("temp = %q, want the extracted directory %q", temp, dir)
+		}
+		check(t, dir)
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		ce := &CommandExecutor{CacheDir: filepath.Join(t.TempDir(), "cache")}
+
+		dir, temp, err := ce.extractArchive(archive)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if temp != "" {
+			t.Errorf("temp = %q for a cached extraction", temp)
+		}
+		sum, _ := checksum(archive)
+		if dir != filepath.Join(ce.CacheDir, sum) {
+			t.Errorf("extracted into %q, want the directory named after the checksum", dir)
+		}
+		check(t, dir)
+
+		// the extraction is reused, and marked as recently used
+		old := time.Now().Add(-time.Hour)
+		os.Chtimes(dir, old, old)
+		os.Remove(filepath.Join(dir, "readme.txt"))
+		if again, _, err := ce.extractArchive(archive); err != nil || again != dir {
+			t.Fatalf("second extraction = %q, %v, want %q", again, err, dir)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "readme.txt")); !os.IsNotExist(err) {
+			t.Error("archive extracted again")
+		}
+		if info, _ := os.Stat(dir); !info.ModTime().After(old) {
+			t.Error("extraction not marked as used")
+		}
+
+		if names := listDir(t, ce.CacheDir); !slices.Equal(names, []string{sum}) {
+			t.Errorf("cache holds %q, want only the extraction", names)
+		}
+	})
+
+	t.Run("zip slip", func(t *testing.T) {
+		ce := &CommandExecutor{CacheDir: filepath.Join(t.TempDir(), "cache")}
+		evil := writeZip(t, t.TempDir(), map[string]string{"../evil.exe": "evil"})
+
+		if _, _, err := ce.extractArchive(evil); err == nil {
+			t.Fatal("extraction of an entry outside the directory succeeded")
+		}
+		if names := listDir(t, ce.CacheDir); len(names) != 0 {
+			t.Errorf("cache holds %q after a failed extraction", names)
+		}
+	})
+}
+
+func TestPruneCache(t *testing.T) {
+	ce := &CommandExecutor{CacheDir: t.TempDir(), CacheRetention: 24 * time.Hour}
+
+	mkdir := func(name string, age time.Duration) {
+		path := filepath.Join(ce.CacheDir, name)
+		if err := os.Mkdir(path, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		os.Chtimes(path, time.Now().Add(-age), time.Now().Add(-age))
+	}
+	mkdir("expired", 48*time.Hour)
+	mkdir("recent", time.Hour)
+	mkdir("recent.partial-1", 0)
+	if err := os.WriteFile(filepath.Join(ce.CacheDir, "file"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ce.pruneCache(); err != nil {
+		t.Fatal(err)
+	}
+	if names := listDir(t, ce.CacheDir); !slices.Equal(names, []string{"file", "recent"}) {
+		t.Errorf("cache holds %q, want file and recent", names)
+	}
+}
+
+func TestPruneCacheDuringExtraction(t *testing.T) {
+	ce := &CommandExecutor{CacheDir: t.TempDir()}
+	src := t.TempDir()
+
+	var archives []string
+	for i := range 10 {
+		files := map[string]string{"setup.exe": fmt.Sprint(i)}
+		for j := range 50 {
+			files[fmt.Sprintf("data/%d.bin", j)] = fmt.Sprint(i, j)
+		}
+		archives = append(archives, writeZip(t, src, files))
+	}
+
+	// partial directories of the extractions in progress are not removed as leftovers
+	var wg sync.WaitGroup
+	for _, archive := range archives {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if dir, _, err := ce.extractArchive(archive); err != nil {
+				t.Error(err)
+			} else if _, err := os.Stat(filepath.Join(dir, "setup.exe")); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if err := ce.pruneCache(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
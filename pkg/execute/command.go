@@ -1,107 +1,277 @@
 package execute
 
 import (
-	"bytes"
+	"driver-box/pkg/storage"
 	"errors"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/saintfish/chardet"
-	"github.com/shirou/gopsutil/v3/process"
-	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding"
 )
 
 type Command struct {
 	cmd       *exec.Cmd
 	startTime time.Time
-	stdout    bytes.Buffer
-	stderr    bytes.Buffer
-	stopped   bool
+	stdout    capture
+	stderr    capture
+	logs      [2]string // Paths of the stdout and stderr log files, if any
+	temp      []string  // Temporary files and directories removed once the command has exited
+	setupLog  string    // Path of the log written by the installer, for drivers of [storage.KindMsi]
+	stopped   atomic.Bool
+	driver    storage.Driver // Definition of the driver, or a zero value for an arbitrary command
+	class     string         // Resource class of the driver group
+	result    atomic.Pointer[CommandResult]
+	attempts  int
+	pid       int  // PID of the running process, zero before it starts and once it has exited
+	exited    bool // Whether the process of the current attempt has exited
+	peak      Usage
+	events    []Event
+	mu        sync.Mutex
+}
+
+// Event is an entry in the history of a command.
+type Event struct {
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"` // "queued", "started", "requeued", "exited" or "aborted"
+	Message string    `json:"message"`
 }
 
 func NewCommand(program string, options []string) *Command {
 	wrapper := Command{cmd: exec.Command(program, options...)}
 	wrapper.cmd.Stdout = &wrapper.stdout
 	wrapper.cmd.Stderr = &wrapper.stderr
+	newProcessGroup(wrapper.cmd)
 	return &wrapper
 }
 
+// Sets the working directory of the command.
+func (t *Command) SetDir(dir string) {
+	t.cmd.Dir = dir
+}
+
+// Declares the encoding of stdout and stderr, which is detected from the output if enc is nil.
+func (t *Command) SetEncoding(enc encoding.Encoding) {
+	t.stdout.setEncoding(enc)
+	t.stderr.setEncoding(enc)
+}
+
+// Appends environment variables, in the form of "KEY=value", on top of the current process environment.
+func (t *Command) SetEnv(env []string) {
+	t.cmd.Env = append(os.Environ(), env...)
+}
+
+// Writes the full stdout and stderr of the command into log files in dir, named after id.
+func (t *Command) openLogs(dir string, id string) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	prefix := filepath.Join(dir, time.Now().Format(logTimeFormat)+"_"+id)
+	logs := [2]string{prefix + ".stdout.log", prefix + ".stderr.log"}
+
+	if err := t.stdout.open(logs[0]); err != nil {
+		return err
+	}
+	if err := t.stderr.open(logs[1]); err != nil {
+		t.stdout.close()
+		return err
+	}
+
+	t.logs = logs
+	return nil
+}
+
+func (t *Command) closeLogs() error {
+	return errors.Join(t.stdout.close(), t.stderr.close())
+}
+
+// Removes the temporary files and directories of the command.
+func (t *Command) removeTemp() error {
+	var errs []error
+	for _, path := range t.temp {
+		if err := os.RemoveAll(path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	t.temp = nil
+	return errors.Join(errs...)
+}
+
+// Appends an event to the history of the command.
+func (t *Command) record(kind string, message string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.events = append(t.events, Event{Time: time.Now(), Type: kind, Message: message})
+}
+
+// Returns a copy of the history of the command.
+func (t *Command) History() []Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]Event(nil), t.events...)
+}
+
+// Counts a new attempt to run the command, and returns its number.
+func (t *Command) attempt() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.attempts++
+	return t.attempts
+}
+
+// Returns the peak resource usage of the command.
+func (t *Command) peakUsage() Usage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.peak
+}
+
+// Prepares an exited command to be started again, with the output of the previous run discarded from memory.
+// The log files, if any, keep the output of every run.
+func (t *Command) reset() {
+	cmd := &exec.Cmd{
+		Path:        t.cmd.Path,
+		Args:        t.cmd.Args,
+		Env:         t.cmd.Env,
+		Dir:         t.cmd.Dir,
+		SysProcAttr: t.cmd.SysProcAttr,
+		Stdout:      &t.stdout,
+		Stderr:      &t.stderr,
+	}
+
+	t.stdout.Reset()
+	t.stderr.Reset()
+
+	t.mu.Lock()
+	t.cmd = cmd
+	t.startTime = time.Time{}
+	t.pid = 0
+	t.exited = false
+	t.peak = Usage{}
+	t.mu.Unlock()
+}
+
+// Starts the command, unless it was aborted after being dispatched. The lock is held while starting,
+// so that [Command.Terminate] either prevents the start or sees the process.
 func (t *Command) Start() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stopped.Load() {
+		return errors.New("execute: command aborted before it started")
+	}
+
 	t.startTime = time.Now()
-	return t.cmd.Start()
+	if err := t.cmd.Start(); err != nil {
+		return err
+	}
+	t.pid = t.cmd.Process.Pid
+	return nil
 }
 
 func (t *Command) Wait() error {
+	defer t.flush()
+	defer t.markExited()
 	return t.cmd.Wait()
 }
 
 func (t *Command) Run() error {
+	defer t.flush()
+	defer t.markExited()
+
+	t.mu.Lock()
 	t.startTime = time.Now()
+	t.mu.Unlock()
+
 	return t.cmd.Run()
 }
 
+// Forgets the PID of the exited process, as it may be reused by an unrelated process.
+func (t *Command) markExited() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pid = 0
+	t.exited = true
+}
+
+// Decodes the output remaining in the buffers once the process has exited.
+func (t *Command) flush() {
+	t.stdout.flush()
+	t.stderr.flush()
+}
+
+// Stops the command and all of its descendant processes, see [Command.Terminate].
 func (t *Command) Stop() error {
-	if t.cmd.Process == nil {
-		panic("execute: called Stop before command started")
+	return t.Terminate(defaultGracePeriod)
+}
+
+// Asks the command and all of its descendant processes to terminate, and kills those
+// still running after the grace period. The processes are killed immediately if grace is not positive.
+// A command dispatched but not started yet is prevented from starting, and an exited command is left untouched.
+func (t *Command) Terminate(grace time.Duration) error {
+	t.mu.Lock()
+	pid, exited := t.pid, t.exited
+	if pid == 0 && !exited {
+		t.stopped.Store(true)
 	}
+	t.mu.Unlock()
 
-	proc, err := process.NewProcess(int32(t.cmd.Process.Pid))
-	if err != nil {
-		return err
+	if exited {
+		return errors.New("execute: process does not exist, the command has exited")
 	}
 
-	if children, err := proc.Children(); err != nil {
+	if pid == 0 {
+		return nil
+	}
+
+	tree, err := processTree(int32(pid))
+	if err != nil {
 		return err
-	} else {
-		var errorChain error = nil
-		for _, p := range children {
-			if err = p.Kill(); err != nil {
-				errorChain = errors.Join(errorChain, err)
-			}
-		}
+	}
 
-		if err := proc.Kill(); err != nil {
-			errorChain = errors.Join(errorChain, err)
-		}
+	t.stopped.Store(true)
 
-		t.stopped = errorChain == nil
+	if grace > 0 {
+		// errors are ignored as the processes are killed anyway if they survive
+		signalTree(pid, tree, false)
+		tree = waitTree(tree, grace)
+	}
 
-		return errorChain
+	var errorChain error
+	if len(tree) > 0 {
+		errorChain = signalTree(pid, tree, true)
 	}
+
+	t.stopped.Store(errorChain == nil)
+
+	return errorChain
 }
 
-func (t Command) Lapse() float32 {
+func (t *Command) Lapse() float32 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	if t.startTime.Year() == 1 {
 		return -1.0
 	}
 	return float32(time.Since(t.startTime).Milliseconds()) / 1000
 }
 
-func (t Command) DecodeStdout() string {
-	if s, err := t.DecodeStdPipe(t.stdout); err != nil {
-		return t.stdout.String()
-	} else {
-		return s
-	}
+// Returns the stdout kept in memory, which is decoded as it is written.
+func (t *Command) DecodeStdout() string {
+	return t.stdout.String()
 }
 
-func (t Command) DecodeStderr() string {
-	if s, err := t.DecodeStdPipe(t.stderr); err != nil {
-		return t.stdout.String()
-	} else {
-		return s
-	}
-}
-
-func (t Command) DecodeStdPipe(buff bytes.Buffer) (string, error) {
-	detector := chardet.NewTextDetector()
-	if result, err := detector.DetectBest(buff.Bytes()); err == nil {
-		if encoding, _ := charset.Lookup(result.Charset); encoding != nil {
-			return encoding.NewDecoder().String(buff.String())
-		} else {
-			return "", err
-		}
-	} else {
-		return "", err
-	}
+// Returns the stderr kept in memory, which is decoded as it is written.
+func (t *Command) DecodeStderr() string {
+	return t.stderr.String()
 }
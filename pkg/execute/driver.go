@@ -0,0 +1,208 @@
+package execute
+
+import (
+	"driver-box/pkg/installer"
+	"driver-box/pkg/porter"
+	"driver-box/pkg/status"
+	"driver-box/pkg/storage"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Resource class of the Windows Installer packages.
+const msiexecClass = "msiexec"
+
+// Builds the command of a driver stored in a group, see [CommandExecutor.newDriverCommand].
+func (ce *CommandExecutor) driverCommand(groupId string, driverId string) (*Command, error) {
+	if ce.Groups == nil {
+		return nil, errors.New("execute: no driver group manager attached")
+	}
+
+	if _, err := ce.Groups.Read(); err != nil {
+		return nil, err
+	}
+
+	group, err := ce.Groups.Get(groupId)
+	if err != nil {
+		return nil, err
+	}
+
+	index := slices.IndexFunc(group.Drivers, func(d storage.Driver) bool { return d.Id == driverId })
+	if index == -1 {
+		return nil, errors.New("execute: no driver with the same ID was found in the group")
+	}
+	return ce.newDriverCommand(group, group.Drivers[index])
+}
+
+// Builds the command of a driver of group, with placeholders in its path, flags, environment
+// variables and working directory expanded.
+func (ce *CommandExecutor) newDriverCommand(group storage.DriverGroup, driver storage.Driver) (*Command, error) {
+	enc, err := lookupEncoding(driver.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	placeholders := ce.Placeholders.With("GROUP_DIR", filepath.Join(ce.Placeholders["DRIVERS"], string(group.Type)))
+
+	var (
+		program string
+		options []string
+		temp    string
+		workDir = driver.WorkDir
+		logPath string // Log written by the installer itself
+	)
+
+	switch driver.Kind {
+	case "", storage.KindExecutable:
+		program = placeholders.Expand(driver.Path)
+		if driver.WorkDir != "" && !filepath.IsAbs(program) && strings.ContainsAny(program, `/\`) {
+			// a relative path would otherwise be resolved against the new working directory
+			if abs, err := filepath.Abs(program); err == nil {
+				program = abs
+			}
+		}
+		options = placeholders.ExpandAll(driver.Flags)
+	case storage.KindScript:
+		// the script body is not expanded, as placeholders clash with the variable syntax of the interpreters
+		if program, options, temp, err = scriptCommand(driver.Interpreter, driver.Script, placeholders.ExpandAll(driver.Flags)); err != nil {
+			return nil, err
+		}
+	case storage.KindArchive:
+		dir, tempDir, err := ce.extractArchive(placeholders.Expand(driver.Path))
+		if err != nil {
+			return nil, err
+		}
+		temp = tempDir
+
+		if program, err = porter.SafeJoin(dir, driver.Entry); err != nil {
+			os.RemoveAll(temp)
+			return nil, err
+		}
+		if workDir == "" {
+			// installers often expect to be started from their own directory
+			workDir = filepath.Dir(program)
+		}
+
+		placeholders = placeholders.With("ARCHIVE_DIR", dir)
+		options = placeholders.ExpandAll(driver.Flags)
+	case storage.KindMsi:
+		path, err := filepath.Abs(placeholders.Expand(driver.Path))
+		if err != nil {
+			return nil, err
+		}
+
+		logDir := ce.LogDir
+		if logDir == "" {
+			logDir = os.TempDir()
+		}
+		if err := os.MkdirAll(logDir, os.ModePerm); err != nil {
+			return nil, err
+		}
+		logPath = filepath.Join(logDir, time.Now().Format(logTimeFormat)+"_"+driver.Id+".msi.log")
+
+		// output rules may read the log through the placeholder
+		placeholders = placeholders.With("INSTALLER_LOG", logPath)
+		program, options = msiCommand(path, logPath, placeholders.ExpandAll(driver.Flags))
+	case storage.KindInf:
+		path, err := filepath.Abs(placeholders.Expand(driver.Path))
+		if err != nil {
+			return nil, err
+		}
+		program, options = infCommand(path, placeholders.ExpandAll(driver.Flags))
+	default:
+		return nil, errors.New("execute: unknown driver kind")
+	}
+
+	command := NewCommand(program, options)
+	command.setupLog = logPath
+	if temp != "" {
+		command.temp = append(command.temp, temp)
+	}
+	if driver.Kind == storage.KindScript {
+		hideProcessWindow(command.cmd)
+	}
+	if err := checkSignature(group.SignaturePolicy, driver.Kind, command); err != nil {
+		command.removeTemp()
+		return nil, err
+	}
+	command.driver = driver
+	command.driver.OutputRules = slices.Clone(driver.OutputRules)
+	for i, rule := range command.driver.OutputRules {
+		if rule.Source != storage.SourceStdout && rule.Source != storage.SourceStderr {
+			command.driver.OutputRules[i].Source = placeholders.Expand(rule.Source)
+		}
+	}
+	command.class = group.ResourceClass
+	if driver.Kind == storage.KindMsi {
+		// Windows Installer runs one installation at a time, whatever the group competes for
+		command.class = msiexecClass
+	}
+	command.SetEncoding(enc)
+	if workDir != "" {
+		command.SetDir(placeholders.Expand(workDir))
+	}
+	if len(driver.Env) > 0 {
+		command.SetEnv(placeholders.ExpandAll(driver.Env))
+	}
+	return command, nil
+}
+
+// Checks the executable of a command against the signature policy of its group.
+// Violations are recorded as events under a warn policy, and returned under a block policy.
+// Scripts are exempt, as their body is part of the settings. The signatures of Windows Installer packages
+// and INF driver packages are not inspected, so they are violations of any policy.
+func checkSignature(policy storage.SignaturePolicy, kind storage.DriverKind, command *Command) error {
+	if policy.Action == storage.PolicyNone || kind == storage.KindScript {
+		return nil
+	}
+
+	var err error
+	switch kind {
+	case "", storage.KindExecutable, storage.KindArchive:
+		var sig installer.Signature
+		if sig, err = installer.InspectSignatureFile(command.cmd.Path); err == nil {
+			err = installer.CheckPolicy(policy, sig)
+		}
+	default:
+		err = fmt.Errorf("execute: the signature of %s drivers cannot be inspected", kind)
+	}
+	if err == nil {
+		return nil
+	}
+
+	if policy.Action == storage.PolicyBlock {
+		return errors.Join(err, errors.New("execute: blocked by the signature policy"))
+	}
+	command.record("signature", err.Error())
+	return nil
+}
+
+// Determines the final status of a driver from its execution result.
+func evaluate(driver storage.Driver, result CommandResult) status.Status {
+	if result.Aborted {
+		return status.Aborted
+	}
+	if result.ExitCode == -1 {
+		// the process was not started or could not be waited
+		return status.Errored
+	}
+
+	switch driver.ExitCodeMeaning(int32(result.ExitCode)) {
+	case storage.ExitReboot:
+		return status.RebootRequired
+	case storage.ExitSkipped:
+		return status.Skiped
+	case storage.ExitSuccess:
+		if result.Lapse < driver.MinExeTime {
+			return status.Speeded
+		}
+		return status.Completed
+	default:
+		return status.Failed
+	}
+}
@@ -0,0 +1,39 @@
+package execute
+
+import (
+	"driver-box/pkg/storage"
+	"os"
+	"testing"
+)
+
+func TestCheckSignature(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     storage.PolicyAction
+		kind       storage.DriverKind
+		wantErr    bool
+		wantEvents int
+	}{
+		{"not checked", storage.PolicyNone, storage.KindMsi, false, 0},
+		{"script", storage.PolicyBlock, storage.KindScript, false, 0},
+		{"msi blocked", storage.PolicyBlock, storage.KindMsi, true, 0},
+		{"inf blocked", storage.PolicyBlock, storage.KindInf, true, 0},
+		{"msi warned", storage.PolicyWarn, storage.KindMsi, false, 1},
+		{"inf warned", storage.PolicyWarn, storage.KindInf, false, 1},
+		{"unsigned executable blocked", storage.PolicyBlock, storage.KindExecutable, true, 0},
+		{"unsigned executable warned", storage.PolicyWarn, "", false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the test binary is not signed
+			command := NewCommand(os.Args[0], nil)
+			err := checkSignature(storage.SignaturePolicy{Action: tt.action}, tt.kind, command)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkSignature() = %v, want error %v", err, tt.wantErr)
+			}
+			if n := countEvents(command.History(), "signature"); n != tt.wantEvents {
+				t.Errorf("%d signature events, want %d", n, tt.wantEvents)
+			}
+		})
+	}
+}
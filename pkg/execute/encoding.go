@@ -0,0 +1,207 @@
+package execute
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/saintfish/chardet"
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// Maximum bytes of output buffered for detecting the encoding before decoding starts.
+const detectLimit = 64 << 10
+
+// WHATWG labels of Windows code pages, for encodings declared as "cp950" or "windows-950".
+var codePages = map[int]string{
+	866:   "ibm866",
+	874:   "windows-874",
+	932:   "shift_jis",
+	936:   "gbk",
+	949:   "euc-kr",
+	950:   "big5",
+	1200:  "utf-16le",
+	1201:  "utf-16be",
+	1250:  "windows-1250",
+	1251:  "windows-1251",
+	1252:  "windows-1252",
+	1253:  "windows-1253",
+	1254:  "windows-1254",
+	1255:  "windows-1255",
+	1256:  "windows-1256",
+	1257:  "windows-1257",
+	1258:  "windows-1258",
+	20866: "koi8-r",
+	54936: "gb18030",
+	65001: "utf-8",
+}
+
+// OEM code pages of console programs, which have no WHATWG label.
+var oemCodePages = map[int]encoding.Encoding{
+	437: charmap.CodePage437,
+	850: charmap.CodePage850,
+	852: charmap.CodePage852,
+	855: charmap.CodePage855,
+	858: charmap.CodePage858,
+	860: charmap.CodePage860,
+	862: charmap.CodePage862,
+	863: charmap.CodePage863,
+	865: charmap.CodePage865,
+}
+
+// Returns the encoding of a WHATWG label or a Windows code page such as "cp950".
+// A nil encoding is returned for "" and "auto", meaning that the encoding is detected from the output.
+func lookupEncoding(name string) (encoding.Encoding, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || name == "auto" {
+		return nil, nil
+	}
+
+	for _, prefix := range []string{"cp", "windows-", "ibm"} {
+		if number, ok := strings.CutPrefix(name, prefix); ok {
+			if page, err := strconv.Atoi(number); err == nil {
+				if enc := codePageEncoding(page); enc != nil {
+					return enc, nil
+				}
+			}
+		}
+	}
+
+	if enc, _ := charset.Lookup(name); enc != nil {
+		return enc, nil
+	}
+	return nil, fmt.Errorf("execute: unknown output encoding %q", name)
+}
+
+// Returns the encoding of a Windows code page, or nil if unknown.
+func codePageEncoding(page int) encoding.Encoding {
+	if enc, ok := oemCodePages[page]; ok {
+		return enc
+	}
+	if label, ok := codePages[page]; ok {
+		enc, _ := charset.Lookup(label)
+		return enc
+	}
+	return nil
+}
+
+// Detects the encoding of the beginning of an output, see [detect].
+func detectEncoding(b []byte) (enc encoding.Encoding, conclusive bool) {
+	return detect(b, systemCodePages())
+}
+
+// Detects the encoding of the beginning of an output. Checked in order are byte order marks,
+// UTF-16 without a byte order mark, UTF-8, the first of the system code pages decoding the output
+// without invalid sequences, and the guess of chardet.
+// The result is inconclusive if b is plain ASCII so far, in which case UTF-8 is returned.
+func detect(b []byte, pages []int) (enc encoding.Encoding, conclusive bool) {
+	for _, bom := range [][]byte{{0xEF, 0xBB, 0xBF}, {0xFF, 0xFE}, {0xFE, 0xFF}} {
+		// wait for the rest of a byte order mark written in parts
+		if len(b) < len(bom) && bytes.HasPrefix(bom, b) {
+			return unicode.UTF8, false
+		}
+	}
+
+	switch {
+	case bytes.HasPrefix(b, []byte{0xEF, 0xBB, 0xBF}):
+		return unicode.UTF8BOM, true
+	case bytes.HasPrefix(b, []byte{0xFF, 0xFE}):
+		return unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM), true
+	case bytes.HasPrefix(b, []byte{0xFE, 0xFF}):
+		return unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM), true
+	}
+
+	if endian, ok := detectUTF16(b); ok {
+		return unicode.UTF16(endian, unicode.IgnoreBOM), true
+	}
+
+	ascii := true
+	for _, c := range b {
+		if c >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return unicode.UTF8, false
+	}
+
+	// the output may end in the middle of a character
+	if utf8.Valid(b[:len(b)-incompleteRune(b)]) {
+		return unicode.UTF8, true
+	}
+
+	// single byte code pages decode anything, the order of the pages decides between them
+	for _, page := range pages {
+		if enc := codePageEncoding(page); enc != nil && decodesCleanly(enc, b) {
+			return enc, true
+		}
+	}
+
+	if result, err := chardet.NewTextDetector().DetectBest(b); err == nil {
+		if enc, _ := charset.Lookup(result.Charset); enc != nil {
+			return enc, true
+		}
+	}
+	return unicode.UTF8, true
+}
+
+// Reports whether b decodes with enc without invalid sequences, ignoring a character cut at the end.
+func decodesCleanly(enc encoding.Encoding, b []byte) bool {
+	// a byte decodes into at most three bytes of UTF-8 in the supported code pages
+	dst := make([]byte, 3*len(b)+utf8.UTFMax)
+	n, _, err := enc.NewDecoder().Transform(dst, b, false)
+	if err != nil && !errors.Is(err, transform.ErrShortSrc) {
+		return false
+	}
+	return !bytes.ContainsRune(dst[:n], utf8.RuneError)
+}
+
+// Reports the byte order of UTF-16 text without a byte order mark, which is recognised by
+// the zero bytes of ASCII characters. Programs like cmd /u and wmic write such output.
+func detectUTF16(b []byte) (unicode.Endianness, bool) {
+	b = b[:min(len(b), 512)&^1]
+	if len(b) < 4 {
+		return unicode.LittleEndian, false
+	}
+
+	var even, odd int
+	for i := 0; i < len(b); i += 2 {
+		if b[i] == 0 {
+			even++
+		}
+		if b[i+1] == 0 {
+			odd++
+		}
+	}
+
+	pairs := len(b) / 2
+	switch {
+	case odd*2 > pairs && even*8 < pairs:
+		return unicode.LittleEndian, true
+	case even*2 > pairs && odd*8 < pairs:
+		return unicode.BigEndian, true
+	default:
+		return unicode.LittleEndian, false
+	}
+}
+
+// Returns the number of bytes at the end of b belonging to an incomplete UTF-8 character.
+func incompleteRune(b []byte) int {
+	for i := 1; i <= min(len(b), utf8.UTFMax-1); i++ {
+		if c := b[len(b)-i]; utf8.RuneStart(c) {
+			if !utf8.FullRune(b[len(b)-i:]) {
+				return i
+			}
+			return 0
+		}
+	}
+	return 0
+}
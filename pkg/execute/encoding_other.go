@@ -0,0 +1,8 @@
+//go:build !windows
+
+package execute
+
+// Programs outside of Windows are expected to write UTF-8, which is detected before the system code pages.
+func systemCodePages() []int {
+	return nil
+}
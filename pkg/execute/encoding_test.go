@@ -0,0 +1,129 @@
+package execute
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// Encodes text, failing the test if the encoding cannot represent it.
+func encode(t *testing.T, enc encoding.Encoding, text string) []byte {
+	t.Helper()
+	b, err := enc.NewEncoder().Bytes([]byte(text))
+	if err != nil {
+		t.Fatalf("encode %q: %v", text, err)
+	}
+	return b
+}
+
+// Looks up an encoding by name, failing the test if it is unknown.
+func lookup(t *testing.T, name string) encoding.Encoding {
+	t.Helper()
+	enc, err := lookupEncoding(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return enc
+}
+
+// Returns the name of an encoding, also of the wrappers returned by a lookup of its label.
+func encodingName(enc encoding.Encoding) string {
+	return strings.Trim(fmt.Sprint(enc), "&{}")
+}
+
+func TestDecodeCorpus(t *testing.T) {
+	const chinese = "安裝完成，請重新啟動電腦。\r\n"
+	const english = "Installation complete.\r\n"
+	const oem = "Überprüfung abgeschlossen ▒ 100%\r\n"
+
+	tests := []struct {
+		name     string
+		declared string // Declared encoding, empty to detect it
+		pages    []int  // System code pages used for detection
+		raw      []byte
+		want     string
+	}{
+		{"GBK declared", "cp936", nil, encode(t, simplifiedchinese.GBK, chinese), chinese},
+		{"GBK detected", "", []int{936, 1252}, encode(t, simplifiedchinese.GBK, chinese), chinese},
+		{"Big5 declared", "cp950", nil, encode(t, traditionalchinese.Big5, chinese), chinese},
+		{"Big5 detected", "", []int{950, 1252}, encode(t, traditionalchinese.Big5, chinese), chinese},
+		{"UTF-16LE BOM", "", nil, encode(t, unicode.UTF16(unicode.LittleEndian, unicode.UseBOM), chinese), chinese},
+		{"UTF-16BE BOM", "", nil, encode(t, unicode.UTF16(unicode.BigEndian, unicode.UseBOM), chinese), chinese},
+		{"UTF-16LE", "", nil, encode(t, unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM), english), english},
+		{"UTF-16BE", "", nil, encode(t, unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM), english), english},
+		{"UTF-16LE declared", "utf-16le", nil, encode(t, unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM), chinese), chinese},
+		{"UTF-8", "", []int{950, 1252}, []byte(chinese), chinese},
+		{"UTF-8 BOM", "", nil, append([]byte{0xEF, 0xBB, 0xBF}, chinese...), chinese},
+		{"OEM declared", "cp437", nil, encode(t, charmap.CodePage437, oem), oem},
+		{"OEM detected", "", []int{437, 1252}, encode(t, charmap.CodePage437, oem), oem},
+		{"OEM before ANSI", "", []int{850, 1252}, encode(t, charmap.CodePage850, oem), oem},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var enc encoding.Encoding
+			if tt.declared != "" {
+				enc = lookup(t, tt.declared)
+			} else {
+				enc, _ = detect(tt.raw, tt.pages)
+			}
+
+			got, err := enc.NewDecoder().Bytes(tt.raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("decoded %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name           string
+		raw            []byte
+		pages          []int
+		want           string
+		wantConclusive bool
+	}{
+		{"ASCII", []byte("Extracting files..."), []int{936}, "utf-8", false},
+		{"truncated UTF-8", []byte("完成")[:5], []int{936}, "utf-8", true},
+		{"invalid in first page", []byte("\x80 5%"), []int{950, 1252}, "windows-1252", true},
+		{"first page", encode(t, simplifiedchinese.GBK, "完成"), []int{936, 950}, "gbk", true},
+		{"truncated character", encode(t, simplifiedchinese.GBK, "完成")[:3], []int{936, 1252}, "gbk", true},
+		{"unknown page", encode(t, charmap.CodePage437, "Größe"), []int{1, 437}, "cp437", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conclusive := detect(tt.raw, tt.pages)
+			name, want := encodingName(got), encodingName(lookup(t, tt.want))
+			if name != want || conclusive != tt.wantConclusive {
+				t.Errorf("detect = %s, %v, want %s, %v", name, conclusive, want, tt.wantConclusive)
+			}
+		})
+	}
+}
+
+func TestCaptureIncremental(t *testing.T) {
+	const text = "正在安裝驅動程式…\r\n"
+	raw := encode(t, unicode.UTF16(unicode.LittleEndian, unicode.UseBOM), text)
+
+	var c capture
+	for i := range raw {
+		c.Write(raw[i : i+1])
+	}
+	c.flush()
+
+	if got := c.String(); got != text {
+		t.Errorf("captured %q, want %q", got, text)
+	}
+	if got, want := c.Encoding(), unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM); got != want {
+		t.Errorf("encoding = %v, want %v", got, want)
+	}
+}
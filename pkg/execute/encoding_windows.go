@@ -0,0 +1,18 @@
+package execute
+
+import "golang.org/x/sys/windows"
+
+var procGetOEMCP = windows.NewLazySystemDLL("kernel32.dll").NewProc("GetOEMCP")
+
+// Returns the code pages programs write their output in, in order of preference: the output code
+// page of the console, or the OEM code page without a console, as installers are usually console
+// programs, then the ANSI code page of GUI programs.
+func systemCodePages() []int {
+	oem, err := windows.GetConsoleOutputCP()
+	if err != nil || oem == 0 {
+		// the application has no console, the consoles of its commands start with the OEM code page
+		cp, _, _ := procGetOEMCP.Call()
+		oem = uint32(cp)
+	}
+	return []int{int(oem), int(windows.GetACP())}
+}
@@ -3,42 +3,103 @@ package execute
 import (
 	"context"
 	"crypto/rand"
+	"driver-box/pkg/status"
+	"driver-box/pkg/storage"
 	"encoding/hex"
 	"errors"
-	"syscall"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/puzpuzpuz/xsync/v3"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 type CommandExecutor struct {
-	ctx      context.Context
-	commands *xsync.MapOf[string, *Command]
+	Groups         *storage.DriverGroupManager
+	Settings       *storage.AppSettingManager
+	Placeholders   Placeholders
+	Retention      time.Duration // Duration for which exited commands are kept, defaults to one hour
+	GracePeriod    time.Duration // Duration for an aborted command to exit before it is killed, defaults to five seconds
+	SampleInterval time.Duration // Interval between resource usage samples, defaults to two seconds
+	RetryDelay     time.Duration // Delay before retrying a command when no other command is running, defaults to five seconds
+	LogDir         string        // Directory of the full output logs, no logs are written if empty
+	LogRetention   time.Duration // Duration for which output logs are kept, defaults to seven days
+	MaxLogs        int           // Maximum number of output log files kept, defaults to 200
+	CacheDir       string        // Directory of extracted archives reused across runs, archives are extracted per run if empty
+	CacheRetention time.Duration // Duration for which unused extracted archives are kept, defaults to seven days
+	ctx            context.Context
+	commands       *xsync.MapOf[string, *Command]
+	pool           pool
+	cacheMu        sync.RWMutex // Held for writing while pruning the cache, and for reading while extracting into it
 }
 
 type CommandResult struct {
-	Lapse    float32 `json:"lapse"`
-	ExitCode int     `json:"exitCode"`
-	Stdout   string  `json:"stdout"`
-	Stderr   string  `json:"stderr"`
-	Error    string  `json:"error"`
-	Aborted  bool    `json:"aborted"`
+	Lapse    float32       `json:"lapse"`
+	ExitCode int           `json:"exitCode"`
+	Stdout   string        `json:"stdout"`
+	Stderr   string        `json:"stderr"`
+	Error    string        `json:"error"`
+	Aborted  bool          `json:"aborted"`
+	Status   status.Status `json:"status"`
+	Events   []Event       `json:"events"`
+	Peak     Usage         `json:"peak"`  // Peak resource usage while running
+	Match    *OutputMatch  `json:"match"` // Output rule deciding the status, if any
+	// Paths of the full stdout and stderr logs, as Stdout and Stderr only keep the end of long outputs
+	StdoutLog string `json:"stdoutLog"`
+	StderrLog string `json:"stderrLog"`
+	// Path of the log written by the installer itself, if any
+	InstallerLog string    `json:"installerLog"`
+	ExitTime     time.Time `json:"exitTime"` // Time the command exited or was removed from the queue
 }
 
 func (ce *CommandExecutor) SetContext(ctx context.Context) {
 	ce.ctx = ctx
 	ce.commands = xsync.NewMapOf[string, *Command]()
+
+	if ce.LogDir != "" {
+		go ce.pruneLogs()
+	}
+	if ce.CacheDir != "" {
+		go ce.pruneCache()
+	}
 }
 
-func (ce *CommandExecutor) Run(program string, options []string) string {
+// Run submits a program with its options as given, placeholders are only expanded for drivers.
+// The command is speeded if it succeeds within minExeTime seconds.
+func (ce *CommandExecutor) Run(program string, options []string, minExeTime float32) string {
+	ce.evict()
+
+	command := NewCommand(program, options)
+	command.driver.MinExeTime = minExeTime
+
 	id := ce.generateId()
-	ce.commands.Store(id, NewCommand(program, options))
+	ce.commands.Store(id, command)
 
-	go ce.dispatch(id)
+	ce.submit(id)
 
 	return id
 }
 
+func (ce *CommandExecutor) RunDriver(groupId string, driverId string) (string, error) {
+	command, err := ce.driverCommand(groupId, driverId)
+	if err != nil {
+		return "", err
+	}
+
+	ce.evict()
+
+	id := ce.generateId()
+	ce.commands.Store(id, command)
+
+	ce.submit(id)
+
+	return id, nil
+}
+
+// RunAndOutput runs a command and waits for it to exit. Stdout and stderr of the result are the
+// output decoded into UTF-8 text, with the encoding detected as for drivers, rather than the raw bytes
+// written by the program; only the last [outputLimit] bytes of each are kept.
 func (ce *CommandExecutor) RunAndOutput(program string, options []string, hideWindow bool) CommandResult {
 	var (
 		errMsg  string
@@ -46,31 +107,47 @@ func (ce *CommandExecutor) RunAndOutput(program string, options []string, hideWi
 	)
 
 	if hideWindow {
-		command.cmd.SysProcAttr = &syscall.SysProcAttr{
-			HideWindow:    true,
-			CreationFlags: 0x08000000,
-		}
+		hideProcessWindow(command.cmd)
 	}
 
 	if err := command.Run(); err != nil {
 		errMsg = err.Error()
 	}
 
-	return CommandResult{
-		command.Lapse(),
-		command.cmd.ProcessState.ExitCode(),
-		command.stdout.String(),
-		command.stderr.String(),
-		errMsg,
-		command.stopped,
+	result := CommandResult{
+		Lapse:    command.Lapse(),
+		ExitCode: command.cmd.ProcessState.ExitCode(),
+		Stdout:   command.stdout.String(),
+		Stderr:   command.stderr.String(),
+		Error:    errMsg,
+		Aborted:  command.stopped.Load(),
+		ExitTime: time.Now(),
 	}
+	result.Status = evaluate(command.driver, result)
+	return result
 }
 
 func (ce *CommandExecutor) Abort(id string) error {
 	if task, ok := ce.commands.Load(id); !ok {
 		return errors.New("execute: id not found")
+	} else if ce.dequeue(id) {
+		task.stopped.Store(true)
+		task.record("aborted", "removed from the queue")
+		// commands waiting for a retry have their logs opened by the first attempt
+		if err := task.closeLogs(); err != nil {
+			task.record("log", err.Error())
+		}
+		if err := task.removeTemp(); err != nil {
+			task.record("cleanup", err.Error())
+		}
+
+		result := CommandResult{Lapse: -1, ExitCode: -1, Aborted: true, Status: status.Aborted, Events: task.History(), ExitTime: time.Now()}
+		task.result.Store(&result)
+
+		ce.emit("execute:exited", id, result)
+		return nil
 	} else {
-		if err := task.Stop(); err != nil {
+		if err := task.Terminate(ce.gracePeriod()); err != nil {
 			return errors.Join(err, errors.New("execute: abort failed"))
 		}
 		return nil
@@ -81,23 +158,140 @@ func (ce *CommandExecutor) dispatch(id string) {
 	if command, ok := ce.commands.Load(id); !ok {
 		panic("execute: id not found")
 	} else {
+		attempt := command.attempt()
+
+		if ce.LogDir != "" && attempt == 1 {
+			if err := command.openLogs(ce.LogDir, id); err != nil {
+				command.record("log", err.Error())
+			}
+		}
+
 		var errMsg string
-		if err := command.Run(); err != nil {
+		if err := command.Start(); err != nil {
 			errMsg = err.Error()
+		} else {
+			command.record("started", fmt.Sprintf("attempt %d", attempt))
+			ce.emit("execute:started", id)
+
+			done := make(chan struct{})
+			go ce.monitor(id, command, command.cmd.Process.Pid, done)
+
+			if err := command.Wait(); err != nil {
+				errMsg = err.Error()
+			}
+			close(done)
 		}
 
-		runtime.EventsEmit(ce.ctx, "execute:exited", id, CommandResult{
-			command.Lapse(),
-			command.cmd.ProcessState.ExitCode(),
-			command.DecodeStdout(),
-			command.DecodeStderr(),
-			errMsg,
-			command.stopped,
-		})
+		result := CommandResult{
+			Lapse:        command.Lapse(),
+			ExitCode:     command.cmd.ProcessState.ExitCode(),
+			Stdout:       command.DecodeStdout(),
+			Stderr:       command.DecodeStderr(),
+			Error:        errMsg,
+			Aborted:      command.stopped.Load(),
+			Peak:         command.peakUsage(),
+			StdoutLog:    command.logs[0],
+			StderrLog:    command.logs[1],
+			InstallerLog: command.setupLog,
+		}
+		result.Status = evaluate(command.driver, result)
+
+		if len(command.driver.OutputRules) > 0 {
+			// the full logs are only needed when the outputs in memory are truncated,
+			// they hold the raw output which is decoded as the output in memory
+			var logs [2]outputLog
+			if command.stdout.Truncated() {
+				logs[0] = outputLog{command.logs[0], command.stdout.Encoding()}
+			}
+			if command.stderr.Truncated() {
+				logs[1] = outputLog{command.logs[1], command.stderr.Encoding()}
+			}
+
+			match, errs := matchRules(command.driver.OutputRules, result.Stdout, result.Stderr, logs)
+			for _, err := range errs {
+				command.record("rule", err.Error())
+			}
+			result.Match = match
+			result.Status = applyMatch(result.Status, match)
+		}
+
+		if !result.Aborted && result.ExitCode != -1 && attempt <= maxRetries &&
+			command.driver.ExitCodeMeaning(int32(result.ExitCode)) == storage.ExitRetryable {
+			command.record("requeued", fmt.Sprintf("exit code %d, another installation is in progress", result.ExitCode))
+			command.reset()
+			ce.postpone(id, command)
+
+			ce.emit("execute:requeued", id, command.History())
+			return
+		}
+
+		if err := command.closeLogs(); err != nil {
+			command.record("log", err.Error())
+		}
+		if err := command.removeTemp(); err != nil {
+			command.record("cleanup", err.Error())
+		}
+
+		command.record("exited", fmt.Sprintf("exit code %d", result.ExitCode))
+		result.Events = command.History()
+		result.ExitTime = time.Now()
+		command.result.Store(&result)
+		ce.release(command)
+
+		ce.emit("execute:exited", id, result)
+	}
+}
+
+// Summarises the statuses of the given commands.
+func (ce *CommandExecutor) Summary(ids []string) status.Summary {
+	statuses := make([]status.Status, 0, len(ids))
+	for _, id := range ids {
+		if command, ok := ce.commands.Load(id); !ok {
+			statuses = append(statuses, status.Errored)
+		} else if result := command.result.Load(); result != nil {
+			statuses = append(statuses, result.Status)
+		} else if ce.queued(id) {
+			statuses = append(statuses, status.Pending)
+		} else {
+			statuses = append(statuses, status.Running)
+		}
+	}
+	return status.Summarise(statuses...)
+}
+
+// Removes output logs exceeding the retention rules.
+func (ce *CommandExecutor) pruneLogs() error {
+	retention, maxLogs := ce.LogRetention, ce.MaxLogs
+	if retention <= 0 {
+		retention = defaultLogRetention
+	}
+	if maxLogs <= 0 {
+		maxLogs = defaultMaxLogs
+	}
+	return pruneLogs(ce.LogDir, retention, maxLogs)
+}
+
+func (ce *CommandExecutor) retryDelay() time.Duration {
+	if ce.RetryDelay <= 0 {
+		return defaultRetryDelay
+	}
+	return ce.RetryDelay
+}
+
+func (ce *CommandExecutor) gracePeriod() time.Duration {
+	if ce.GracePeriod <= 0 {
+		return defaultGracePeriod
+	}
+	return ce.GracePeriod
+}
+
+func (ce *CommandExecutor) emit(event string, data ...interface{}) {
+	if ce.ctx != nil {
+		runtime.EventsEmit(ce.ctx, event, data...)
 	}
 }
 
-func (ce CommandExecutor) generateId() string {
+func (ce *CommandExecutor) generateId() string {
 	id := ""
 	for id == "" {
 		b := make([]byte, 4)
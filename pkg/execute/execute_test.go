@@ -0,0 +1,45 @@
+package execute
+
+import (
+	"driver-box/pkg/status"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no shell available")
+	}
+
+	ce := newTestExecutor(time.Millisecond)
+	ce.Placeholders = Placeholders{"ROOT": "/root"}
+	t.Setenv("DRIVER_BOX_TEST", "expanded")
+
+	tests := []struct {
+		name       string
+		arg        string
+		minExeTime float32
+		status     status.Status
+	}{
+		// e.g. a password set by a built-in task
+		{"placeholders kept", "p${ROOT}%DRIVER_BOX_TEST%", 0, status.Completed},
+		{"speeded", "fast", 10, status.Speeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := ce.Run("sh", []string{"-c", `printf %s "$0"`, tt.arg}, tt.minExeTime)
+			command, _ := ce.commands.Load(id)
+
+			result := waitResult(t, command)
+			if got := strings.TrimSpace(result.Stdout); got != tt.arg {
+				t.Errorf("stdout = %q, want %q", got, tt.arg)
+			}
+			if result.Status != tt.status {
+				t.Errorf("status = %q, want %q", result.Status, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package execute
+
+// Returns the msiexec command line installing the package at path silently and without restarting,
+// with a verbose log written to logPath if not empty. args, such as properties in the form of
+// "NAME=value", are appended.
+func msiCommand(path string, logPath string, args []string) (program string, options []string) {
+	options = []string{"/i", path, "/qn", "/norestart"}
+	if logPath != "" {
+		options = append(options, "/l*v", logPath)
+	}
+	return "msiexec", append(options, args...)
+}
+
+// Returns the pnputil command line adding the driver package of the INF file at path to the
+// driver store and installing it on matching devices. args, such as "/subdirs", are appended.
+func infCommand(path string, args []string) (program string, options []string) {
+	return "pnputil", append([]string{"/add-driver", path, "/install"}, args...)
+}
@@ -0,0 +1,59 @@
+package execute
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMsiCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		logPath string
+		args    []string
+		want    []string
+	}{
+		{"silent", `C:\drivers\audio.msi`, "", nil,
+			[]string{"/i", `C:\drivers\audio.msi`, "/qn", "/norestart"}},
+		{"log", `C:\drivers\audio.msi`, `C:\logs\audio.msi.log`, nil,
+			[]string{"/i", `C:\drivers\audio.msi`, "/qn", "/norestart", "/l*v", `C:\logs\audio.msi.log`}},
+		{"properties", `C:\drivers\my audio.msi`, `C:\logs\audio.msi.log`, []string{"REBOOT=ReallySuppress", "ADDLOCAL=ALL"},
+			[]string{"/i", `C:\drivers\my audio.msi`, "/qn", "/norestart", "/l*v", `C:\logs\audio.msi.log`, "REBOOT=ReallySuppress", "ADDLOCAL=ALL"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program, options := msiCommand(tt.path, tt.logPath, tt.args)
+			if program != "msiexec" {
+				t.Errorf("program = %q, want msiexec", program)
+			}
+			if !slices.Equal(options, tt.want) {
+				t.Errorf("options = %q, want %q", options, tt.want)
+			}
+		})
+	}
+}
+
+func TestInfCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		args []string
+		want []string
+	}{
+		{"install", `C:\drivers\net\e1d.inf`, nil,
+			[]string{"/add-driver", `C:\drivers\net\e1d.inf`, "/install"}},
+		{"arguments", `C:\drivers\net\*.inf`, []string{"/subdirs"},
+			[]string{"/add-driver", `C:\drivers\net\*.inf`, "/install", "/subdirs"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program, options := infCommand(tt.path, tt.args)
+			if program != "pnputil" {
+				t.Errorf("program = %q, want pnputil", program)
+			}
+			if !slices.Equal(options, tt.want) {
+				t.Errorf("options = %q, want %q", options, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package execute
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+const (
+	// Default interval between resource usage samples of a running command
+	defaultSampleInterval = 2 * time.Second
+	// CPU usage, in percent of a single core, below which a command is considered idle
+	idleCpuThreshold = 1.0
+)
+
+// Usage is the resource usage of a command, summed over its process tree.
+type Usage struct {
+	CPU      float64 `json:"cpu"`      // CPU usage in percent of a single core
+	Memory   uint64  `json:"memory"`   // Resident memory in bytes
+	Read     uint64  `json:"read"`     // Total bytes read
+	Write    uint64  `json:"write"`    // Total bytes written
+	Children int     `json:"children"` // Number of descendant processes
+}
+
+// Returns the element-wise maximum of the usages.
+func (u Usage) max(other Usage) Usage {
+	return Usage{
+		CPU:      max(u.CPU, other.CPU),
+		Memory:   max(u.Memory, other.Memory),
+		Read:     max(u.Read, other.Read),
+		Write:    max(u.Write, other.Write),
+		Children: max(u.Children, other.Children),
+	}
+}
+
+// Samples the resource usage of the process tree rooted at pid every interval until done is closed,
+// emitting "execute:usage" events and keeping the peak values in the command.
+// The command is terminated if it stays idle longer than [storage.Driver.IdleTimeout].
+func (ce *CommandExecutor) monitor(id string, command *Command, pid int, done <-chan struct{}) {
+	interval := ce.SampleInterval
+	if interval <= 0 {
+		interval = defaultSampleInterval
+	}
+	idleTimeout := time.Duration(command.driver.IdleTimeout * float32(time.Second))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var (
+		procs     = make(map[int32]*process.Process) // kept across samples for CPU usage calculation
+		last      Usage
+		idleSince = time.Now()
+	)
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		tree, err := processTree(int32(pid))
+		if err != nil {
+			continue
+		}
+
+		usage := measure(tree, procs)
+		command.mu.Lock()
+		command.peak = command.peak.max(usage)
+		command.mu.Unlock()
+
+		ce.emit("execute:usage", id, usage)
+
+		if usage.CPU >= idleCpuThreshold || usage.Read != last.Read || usage.Write != last.Write || usage.Children != last.Children {
+			idleSince = time.Now()
+		}
+		last = usage
+
+		if idleTimeout > 0 && time.Since(idleSince) > idleTimeout {
+			command.record("idle", fmt.Sprintf("no activity for %s", idleTimeout))
+			command.Terminate(ce.gracePeriod())
+			return
+		}
+	}
+}
+
+// Sums the resource usage of the processes. Processes are cached in procs by their PID.
+func measure(tree []*process.Process, procs map[int32]*process.Process) Usage {
+	usage := Usage{Children: len(tree) - 1}
+
+	for _, p := range tree {
+		if cached, ok := procs[p.Pid]; ok {
+			p = cached
+		} else {
+			procs[p.Pid] = p
+		}
+
+		if percent, err := p.Percent(0); err == nil {
+			usage.CPU += percent
+		}
+		if memory, err := p.MemoryInfo(); err == nil {
+			usage.Memory += memory.RSS
+		}
+		if io, err := p.IOCounters(); err == nil {
+			usage.Read += io.ReadBytes
+			usage.Write += io.WriteBytes
+		}
+	}
+	return usage
+}
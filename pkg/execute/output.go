@@ -0,0 +1,234 @@
+package execute
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+const (
+	outputLimit         = 1 << 20 // Maximum bytes of output per stream kept in memory
+	defaultLogRetention = 7 * 24 * time.Hour
+	defaultMaxLogs      = 200
+	logTimeFormat       = "20060102-150405" // Prefix of log file names, sorting them by creation time
+)
+
+// capture keeps the last [outputLimit] bytes of the decoded output in memory, and the raw output in a log file if any.
+//
+// The output is decoded incrementally as it is written. Without a declared encoding,
+// the beginning of the output is buffered until its encoding is detected.
+type capture struct {
+	mu       sync.Mutex
+	mem      memory
+	file     *os.File
+	encoding encoding.Encoding // Declared encoding of the output, or nil to detect it
+	decided  encoding.Encoding // Encoding the output is decoded with, nil until decided
+	decoder  *transform.Writer // Decodes the output into mem, nil until the encoding is decided
+}
+
+func (c *capture) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file != nil {
+		if _, err := c.file.Write(p); err != nil {
+			// keep capturing in memory if the log cannot be written
+			c.file.Close()
+			c.file = nil
+		}
+	}
+
+	if c.decoder != nil {
+		c.decoder.Write(p)
+		return len(p), nil
+	}
+
+	c.mem.Write(p)
+	if enc, conclusive := detectEncoding(c.mem.buf); conclusive || len(c.mem.buf) >= detectLimit {
+		c.decide(enc)
+	}
+	return len(p), nil
+}
+
+// Starts decoding with enc, including the output buffered so far.
+func (c *capture) decide(enc encoding.Encoding) {
+	raw := c.mem.buf
+	c.mem = memory{truncated: c.mem.truncated}
+	c.decided = enc
+	c.decoder = transform.NewWriter(&c.mem, enc.NewDecoder())
+	c.decoder.Write(raw)
+}
+
+// Declares the encoding of the output. The encoding is detected if enc is nil.
+func (c *capture) setEncoding(enc encoding.Encoding) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.encoding = enc
+	c.mem = memory{}
+	c.decided = nil
+	c.decoder = nil
+	if enc != nil {
+		c.decide(enc)
+	}
+}
+
+// Decodes the output remaining in the buffers, after the process has exited.
+func (c *capture) flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.decoder == nil {
+		enc, _ := detectEncoding(c.mem.buf)
+		c.decide(enc)
+	}
+	c.decoder.Close()
+}
+
+// Returns a copy of the decoded output kept in memory.
+func (c *capture) Bytes() []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.mem.tail()
+}
+
+func (c *capture) String() string {
+	return string(c.Bytes())
+}
+
+// Returns the encoding the output is decoded with, which also applies to the raw output in the log file.
+func (c *capture) Encoding() encoding.Encoding {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.decided
+}
+
+// Reports whether part of the output was discarded from memory.
+func (c *capture) Truncated() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.mem.truncated
+}
+
+// Discards the output kept in memory. The log file is left untouched.
+func (c *capture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.mem = memory{}
+	c.decided = nil
+	c.decoder = nil
+	if c.encoding != nil {
+		c.decide(c.encoding)
+	}
+}
+
+// Creates the log file at path, which receives all subsequent output.
+func (c *capture) open(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file != nil {
+		c.file.Close()
+	}
+	c.file = file
+	return nil
+}
+
+func (c *capture) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return nil
+	}
+
+	err := c.file.Close()
+	c.file = nil
+	return err
+}
+
+// memory keeps the last [outputLimit] bytes written.
+type memory struct {
+	buf       []byte
+	truncated bool // Whether earlier output was discarded
+}
+
+func (m *memory) Write(p []byte) (int, error) {
+	m.buf = append(m.buf, p...)
+	if len(m.buf) > outputLimit {
+		m.truncated = true
+		// trimmed only once the buffer doubles, to avoid moving the data on every write
+		if len(m.buf) > 2*outputLimit {
+			m.buf = slices.Delete(m.buf, 0, len(m.buf)-outputLimit)
+		}
+	}
+	return len(p), nil
+}
+
+// Returns a copy of the last [outputLimit] bytes, starting at a character boundary.
+func (m *memory) tail() []byte {
+	start := max(len(m.buf)-outputLimit, 0)
+	for m.truncated && start < len(m.buf) && !utf8.RuneStart(m.buf[start]) {
+		start++
+	}
+	return slices.Clone(m.buf[start:])
+}
+
+// Removes log files in dir last modified before the retention period,
+// and the oldest ones exceeding maxFiles.
+func pruneLogs(dir string, retention time.Duration, maxFiles int) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	type logFile struct {
+		path    string
+		modTime time.Time
+	}
+
+	var logs []logFile
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".log") {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			logs = append(logs, logFile{filepath.Join(dir, entry.Name()), info.ModTime()})
+		}
+	}
+
+	// newest first
+	slices.SortFunc(logs, func(a, b logFile) int {
+		return b.modTime.Compare(a.modTime)
+	})
+
+	var errs []error
+	for i, log := range logs {
+		if i >= maxFiles || time.Since(log.modTime) > retention {
+			if err := os.Remove(log.path); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
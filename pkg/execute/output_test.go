@@ -0,0 +1,115 @@
+package execute
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/unicode"
+)
+
+func TestCaptureLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int // Bytes of output written
+		truncated bool
+	}{
+		{"under the limit", outputLimit - 1, false},
+		{"at the limit", outputLimit, false},
+		{"over the limit", outputLimit + 1, true},
+		{"trimmed in memory", 3*outputLimit + 5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// two-byte characters, so that the limit may split one
+			raw := append(bytes.Repeat([]byte("é"), tt.size/2), bytes.Repeat([]byte("!"), tt.size%2)...)
+
+			var c capture
+			c.setEncoding(unicode.UTF8)
+			log := filepath.Join(t.TempDir(), "stdout.log")
+			if err := c.open(log); err != nil {
+				t.Fatal(err)
+			}
+			for chunk := range slices.Chunk(raw, 4093) {
+				c.Write(chunk)
+			}
+			c.flush()
+			if err := c.close(); err != nil {
+				t.Fatal(err)
+			}
+
+			if c.Truncated() != tt.truncated {
+				t.Errorf("Truncated() = %v, want %v", c.Truncated(), tt.truncated)
+			}
+
+			got := c.Bytes()
+			if len(got) > outputLimit {
+				t.Errorf("%d bytes kept in memory, want at most %d", len(got), outputLimit)
+			}
+			if !utf8.Valid(got) {
+				t.Error("output kept in memory starts in the middle of a character")
+			}
+			if !bytes.HasSuffix(raw, got) || (!tt.truncated && len(got) != len(raw)) {
+				t.Errorf("kept %d bytes which are not the end of the output", len(got))
+			}
+
+			// the log keeps the full output
+			if written, err := os.ReadFile(log); err != nil {
+				t.Fatal(err)
+			} else if !bytes.Equal(written, raw) {
+				t.Errorf("log holds %d bytes, want %d", len(written), len(raw))
+			}
+		})
+	}
+}
+
+func TestPruneLogs(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	create := func(name string, age time.Duration) {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, now.Add(-age), now.Add(-age)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	create("expired.stdout.log", 8*24*time.Hour)
+	for i, name := range []string{"1.log", "2.log", "3.log", "4.log"} {
+		create(name, time.Duration(i)*time.Hour)
+	}
+	// only log files are pruned
+	create("notes.txt", 30*24*time.Hour)
+	if err := os.Mkdir(filepath.Join(dir, "old.log"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pruneLogs(dir, 7*24*time.Hour, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if want := []string{"1.log", "2.log", "3.log", "notes.txt", "old.log"}; !slices.Equal(names, want) {
+		t.Errorf("kept %s, want %s", strings.Join(names, ", "), strings.Join(want, ", "))
+	}
+
+	if err := pruneLogs(filepath.Join(dir, "missing"), time.Hour, 1); err != nil {
+		t.Errorf("pruneLogs() of a missing directory = %v", err)
+	}
+}
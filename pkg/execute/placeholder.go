@@ -0,0 +1,51 @@
+package execute
+
+import (
+	"os"
+	"regexp"
+)
+
+var (
+	placeholderPattern = regexp.MustCompile(`\$\{([A-Za-z0-9_]+)\}`)
+	envVarPattern      = regexp.MustCompile(`%([A-Za-z0-9_()]+)%`)
+)
+
+// Placeholders maps placeholder names (e.g. ROOT for "${ROOT}") to their values.
+type Placeholders map[string]string
+
+// With returns a copy of the placeholders with the given name set to value.
+func (p Placeholders) With(name string, value string) Placeholders {
+	cp := make(Placeholders, len(p)+1)
+	for k, v := range p {
+		cp[k] = v
+	}
+	cp[name] = value
+	return cp
+}
+
+// Expand replaces "${NAME}" placeholders and "%NAME%" environment variables in s.
+// Unknown placeholders and undefined environment variables are left untouched.
+func (p Placeholders) Expand(s string) string {
+	s = placeholderPattern.ReplaceAllStringFunc(s, func(m string) string {
+		if v, ok := p[placeholderPattern.FindStringSubmatch(m)[1]]; ok {
+			return v
+		}
+		return m
+	})
+
+	return envVarPattern.ReplaceAllStringFunc(s, func(m string) string {
+		if v, ok := os.LookupEnv(envVarPattern.FindStringSubmatch(m)[1]); ok {
+			return v
+		}
+		return m
+	})
+}
+
+// ExpandAll applies [Placeholders.Expand] to every element of ss.
+func (p Placeholders) ExpandAll(ss []string) []string {
+	expanded := make([]string, len(ss))
+	for i, s := range ss {
+		expanded[i] = p.Expand(s)
+	}
+	return expanded
+}
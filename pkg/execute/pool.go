@@ -0,0 +1,152 @@
+package execute
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// Maximum number of times a command is retried for a retryable exit code
+	maxRetries = 5
+	// Default delay before retrying a command when there is no other running command to wait for
+	defaultRetryDelay = 5 * time.Second
+)
+
+// Limits the number of commands running at the same time, in total and per resource class.
+type pool struct {
+	mu       sync.Mutex
+	queue    []string       // IDs of the pending commands, in submission order
+	deferred []string       // IDs of the commands waiting for another command to exit before retrying
+	running  int            // Number of running commands
+	classes  map[string]int // Number of running commands per resource class
+	// Limits read from the setting when a batch is submitted to an idle pool
+	limit       int
+	classLimits map[string]int
+}
+
+// Queues the command and starts the pending commands allowed by the limits.
+func (ce *CommandExecutor) submit(id string) {
+	if command, ok := ce.commands.Load(id); ok {
+		command.record("queued", "")
+	}
+
+	ce.pool.mu.Lock()
+	if ce.pool.running == 0 && len(ce.pool.queue) == 0 && len(ce.pool.deferred) == 0 {
+		// read once per batch rather than every time the commands are scheduled
+		ce.pool.limit, ce.pool.classLimits = ce.limits()
+	}
+	ce.pool.queue = append(ce.pool.queue, id)
+	ce.pool.mu.Unlock()
+
+	ce.schedule()
+}
+
+// Starts the pending commands, in order, as long as the concurrency limits are not reached.
+// Commands blocked by their resource class do not block those behind them.
+func (ce *CommandExecutor) schedule() {
+	ce.pool.mu.Lock()
+	defer ce.pool.mu.Unlock()
+
+	limit, classLimits := ce.pool.limit, ce.pool.classLimits
+
+	if ce.pool.classes == nil {
+		ce.pool.classes = make(map[string]int)
+	}
+
+	remaining := make([]string, 0, len(ce.pool.queue))
+	for _, id := range ce.pool.queue {
+		command, ok := ce.commands.Load(id)
+		if !ok {
+			continue
+		}
+
+		if limit > 0 && ce.pool.running >= limit {
+			remaining = append(remaining, id)
+			continue
+		}
+		if classLimit := classLimits[command.class]; command.class != "" && classLimit > 0 && ce.pool.classes[command.class] >= classLimit {
+			remaining = append(remaining, id)
+			continue
+		}
+
+		ce.pool.running++
+		ce.pool.classes[command.class]++
+		go ce.dispatch(id)
+	}
+	ce.pool.queue = remaining
+}
+
+// Frees the slot taken by an exited command and starts the next pending commands.
+// Deferred commands are put back in front of the queue as the exited command no longer holds any resource.
+func (ce *CommandExecutor) release(command *Command) {
+	ce.pool.mu.Lock()
+	ce.pool.running--
+	ce.pool.classes[command.class]--
+	ce.pool.queue = append(ce.pool.deferred, ce.pool.queue...)
+	ce.pool.deferred = nil
+	ce.pool.mu.Unlock()
+
+	ce.schedule()
+}
+
+// Frees the slot taken by a command to be retried. The command is queued again after another
+// command exits, or after [CommandExecutor.RetryDelay] if there is no other running command.
+func (ce *CommandExecutor) postpone(id string, command *Command) {
+	ce.pool.mu.Lock()
+	ce.pool.running--
+	ce.pool.classes[command.class]--
+	ce.pool.deferred = append(ce.pool.deferred, id)
+	idle := ce.pool.running == 0
+	ce.pool.mu.Unlock()
+
+	if idle {
+		time.AfterFunc(ce.retryDelay(), func() {
+			ce.pool.mu.Lock()
+			if index := slices.Index(ce.pool.deferred, id); index != -1 {
+				ce.pool.deferred = slices.Delete(ce.pool.deferred, index, index+1)
+				ce.pool.queue = append(ce.pool.queue, id)
+			}
+			ce.pool.mu.Unlock()
+
+			ce.schedule()
+		})
+	}
+}
+
+// Removes the command from the queue. Returns false if the command is not pending.
+func (ce *CommandExecutor) dequeue(id string) bool {
+	ce.pool.mu.Lock()
+	defer ce.pool.mu.Unlock()
+
+	if index := slices.Index(ce.pool.queue, id); index != -1 {
+		ce.pool.queue = slices.Delete(ce.pool.queue, index, index+1)
+		return true
+	}
+	if index := slices.Index(ce.pool.deferred, id); index != -1 {
+		ce.pool.deferred = slices.Delete(ce.pool.deferred, index, index+1)
+		return true
+	}
+	return false
+}
+
+// Returns true if the command is waiting for a free slot or a retry.
+func (ce *CommandExecutor) queued(id string) bool {
+	ce.pool.mu.Lock()
+	defer ce.pool.mu.Unlock()
+
+	return slices.Contains(ce.pool.queue, id) || slices.Contains(ce.pool.deferred, id)
+}
+
+// Returns the total concurrency limit and the limits per resource class.
+func (ce *CommandExecutor) limits() (int, map[string]int) {
+	if ce.Settings == nil {
+		return 0, nil
+	}
+
+	if setting, err := ce.Settings.Read(); err != nil {
+		return 0, nil
+	} else {
+		return setting.MaxConcurrency, setting.ResourceLimits
+	}
+}
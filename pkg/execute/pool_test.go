@@ -0,0 +1,139 @@
+package execute
+
+import (
+	"driver-box/pkg/status"
+	"driver-box/pkg/storage"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/puzpuzpuz/xsync/v3"
+)
+
+// Returns an executor without a frontend context, retrying commands after delay.
+func newTestExecutor(delay time.Duration) *CommandExecutor {
+	return &CommandExecutor{RetryDelay: delay, commands: xsync.NewMapOf[string, *Command]()}
+}
+
+// Submits a shell script as a driver, which treats the exit code 82 as retryable.
+func submitScript(t *testing.T, ce *CommandExecutor, id string, script string, args ...string) *Command {
+	t.Helper()
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no shell available")
+	}
+
+	command := NewCommand("sh", append([]string{"-c", script}, args...))
+	command.driver = storage.Driver{ExitCodes: map[int32]storage.ExitCodeMeaning{82: storage.ExitRetryable}}
+	ce.commands.Store(id, command)
+	ce.submit(id)
+	return command
+}
+
+// Waits for the result of a command, or fails the test after a few seconds.
+func waitResult(t *testing.T, command *Command) CommandResult {
+	t.Helper()
+
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if result := command.result.Load(); result != nil {
+			return *result
+		}
+	}
+	t.Fatal("command not exited")
+	return CommandResult{}
+}
+
+// Returns a script counting its runs in the file given as its first argument,
+// which exits with the retryable code for the first failures runs.
+func failingScript(failures int) string {
+	return `n=$(cat "$0" 2>/dev/null || echo 0); echo $((n+1)) > "$0"; [ "$n" -ge ` + strconv.Itoa(failures) + ` ] || exit 82`
+}
+
+func countEvents(events []Event, kind string) int {
+	n := 0
+	for _, event := range events {
+		if event.Type == kind {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int // Number of runs exiting with the retryable code before succeeding
+		attempts int
+		exitCode int
+		status   status.Status
+	}{
+		{"no retry", 0, 1, 0, status.Completed},
+		{"succeeds after retries", 2, 3, 0, status.Completed},
+		{"gives up after the maximum retries", maxRetries + 1, maxRetries + 1, 82, status.Failed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ce := newTestExecutor(10 * time.Millisecond)
+			command := submitScript(t, ce, "retry", failingScript(tt.failures), filepath.Join(t.TempDir(), "runs"))
+
+			result := waitResult(t, command)
+			if command.attempts != tt.attempts {
+				t.Errorf("attempts = %d, want %d", command.attempts, tt.attempts)
+			}
+			if result.ExitCode != tt.exitCode {
+				t.Errorf("exit code = %d, want %d", result.ExitCode, tt.exitCode)
+			}
+			if result.Status != tt.status {
+				t.Errorf("status = %s, want %s", result.Status, tt.status)
+			}
+			if requeued := countEvents(result.Events, "requeued"); requeued != tt.attempts-1 {
+				t.Errorf("requeued %d times, want %d", requeued, tt.attempts-1)
+			}
+		})
+	}
+}
+
+func TestRetryAfterOtherCommand(t *testing.T) {
+	// the retry waits for the running command to exit rather than the delay
+	ce := newTestExecutor(time.Minute)
+
+	other := submitScript(t, ce, "other", "sleep 0.3")
+	command := submitScript(t, ce, "retry", failingScript(1), filepath.Join(t.TempDir(), "runs"))
+
+	waitResult(t, other)
+	if result := waitResult(t, command); result.Status != status.Completed || command.attempts != 2 {
+		t.Fatalf("status = %s after %d attempts, want completed after 2", result.Status, command.attempts)
+	}
+}
+
+func TestAbortDeferred(t *testing.T) {
+	ce := newTestExecutor(time.Minute)
+	ce.LogDir = t.TempDir()
+
+	command := submitScript(t, ce, "deferred", "exit 82")
+
+	// waits for the command to be deferred after its first attempt
+	for deadline := time.Now().Add(5 * time.Second); countEvents(command.History(), "requeued") == 0; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("command not requeued")
+		}
+	}
+
+	if err := ce.Abort("deferred"); err != nil {
+		t.Fatal(err)
+	}
+
+	result := waitResult(t, command)
+	if !result.Aborted || result.Status != status.Aborted {
+		t.Fatalf("aborted = %v, status = %s", result.Aborted, result.Status)
+	}
+	if command.stdout.file != nil || command.stderr.file != nil {
+		t.Fatal("logs left open")
+	}
+	if ce.queued("deferred") {
+		t.Fatal("command left in the queue")
+	}
+}
@@ -0,0 +1,63 @@
+package execute
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// Default duration for a command to exit after being asked to terminate, before it is killed.
+const defaultGracePeriod = 5 * time.Second
+
+// Returns the process and all of its descendants, with parents placed before their children.
+func processTree(pid int32) ([]*process.Process, error) {
+	root, err := process.NewProcess(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := []*process.Process{root}
+	for i := 0; i < len(tree); i++ {
+		children, err := tree[i].Children()
+		if err != nil && !errors.Is(err, process.ErrorNoChildren) {
+			if i == 0 {
+				return nil, err
+			}
+			// the process may have exited in the meantime
+			continue
+		}
+
+		for _, child := range children {
+			if !slices.ContainsFunc(tree, func(p *process.Process) bool { return p.Pid == child.Pid }) {
+				tree = append(tree, child)
+			}
+		}
+	}
+	return tree, nil
+}
+
+// Waits for the processes to exit until the timeout, and returns those still alive.
+func waitTree(tree []*process.Process, timeout time.Duration) []*process.Process {
+	deadline := time.Now().Add(timeout)
+	for {
+		tree = slices.DeleteFunc(tree, func(p *process.Process) bool { return !alive(p) })
+		if len(tree) == 0 || time.Now().After(deadline) {
+			return tree
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// Returns true if the process is running. Zombie processes are considered exited.
+func alive(p *process.Process) bool {
+	if running, err := p.IsRunning(); err != nil || !running {
+		return false
+	}
+
+	if statuses, err := p.Status(); err == nil && slices.Contains(statuses, process.Zombie) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,42 @@
+//go:build !windows
+
+package execute
+
+import (
+	"errors"
+	"os/exec"
+	"syscall"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// Starts the command in a new process group.
+func newProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+func hideProcessWindow(cmd *exec.Cmd) {}
+
+// Sends SIGTERM, or SIGKILL if forced, to the process group led by pid and every process of the tree,
+// as descendants may have moved to another process group.
+func signalTree(pid int, tree []*process.Process, force bool) error {
+	signal := syscall.SIGTERM
+	if force {
+		signal = syscall.SIGKILL
+	}
+
+	var errorChain error
+	if err := syscall.Kill(-pid, signal); err != nil && !errors.Is(err, syscall.ESRCH) {
+		errorChain = errors.Join(errorChain, err)
+	}
+
+	for _, p := range tree {
+		if err := p.SendSignal(signal); err != nil && alive(p) {
+			errorChain = errors.Join(errorChain, err)
+		}
+	}
+	return errorChain
+}
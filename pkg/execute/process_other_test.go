@@ -0,0 +1,93 @@
+//go:build !windows
+
+package execute
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+func TestTerminateTree(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		size   int // Number of processes in the tree once started
+		grace  time.Duration
+	}{
+		{
+			name:   "terminated gracefully",
+			script: `sh -c 'sleep 300 & sleep 300' & sleep 300`,
+			size:   4,
+			grace:  5 * time.Second,
+		},
+		{
+			name: "killed after ignoring the termination",
+			// a descendant in its own session leaves the process group of the command
+			script: `trap '' TERM; sh -c 'sh -c "sleep 300" & sleep 300' & setsid sleep 300 & sleep 300`,
+			size:   6,
+			grace:  300 * time.Millisecond,
+		},
+		{
+			name:   "killed immediately",
+			script: `trap '' TERM; sh -c 'sleep 300 & sleep 300' & sleep 300`,
+			size:   4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command := NewCommand("sh", []string{"-c", tt.script})
+			if err := command.Start(); err != nil {
+				t.Skip("no shell available:", err)
+			}
+
+			var tree []*process.Process
+			for deadline := time.Now().Add(5 * time.Second); len(tree) < tt.size; time.Sleep(20 * time.Millisecond) {
+				if time.Now().After(deadline) {
+					command.Terminate(0)
+					t.Fatalf("%d processes started, want %d", len(tree), tt.size)
+				}
+				tree, _ = processTree(int32(command.cmd.Process.Pid))
+			}
+
+			start := time.Now()
+			if err := command.Terminate(tt.grace); err != nil {
+				t.Fatal(err)
+			}
+			command.Wait()
+
+			// graceful terminations do not wait for the grace period to end
+			if tt.grace > time.Second && time.Since(start) >= tt.grace {
+				t.Errorf("terminated after %s, want before the grace period", time.Since(start))
+			}
+			if !command.stopped.Load() {
+				t.Error("command not marked stopped")
+			}
+			if alive := waitTree(tree, time.Second); len(alive) > 0 {
+				t.Errorf("%d processes still alive", len(alive))
+			}
+		})
+	}
+}
+
+func TestTerminateExited(t *testing.T) {
+	command := NewCommand("sh", []string{"-c", "exit 0"})
+	if err := command.Start(); err != nil {
+		t.Skip("no shell available:", err)
+	}
+	command.Wait()
+
+	// the PID of the exited process may have been reused by an unrelated process
+	if err := command.Terminate(0); err == nil || !strings.Contains(err.Error(), "process does not exist") {
+		t.Errorf("Terminate() of an exited command = %v, want an error", err)
+	}
+	if command.stopped.Load() {
+		t.Error("exited command marked stopped")
+	}
+	if info := command.info("exited", false); info.Pid != 0 {
+		t.Errorf("PID of an exited command = %d, want 0", info.Pid)
+	}
+}
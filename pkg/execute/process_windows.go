@@ -0,0 +1,49 @@
+package execute
+
+import (
+	"errors"
+	"os/exec"
+	"strconv"
+	"syscall"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+const (
+	createNewProcessGroup = 0x00000200
+	createNoWindow        = 0x08000000
+)
+
+// Starts the command in a new process group.
+func newProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.CreationFlags |= createNewProcessGroup
+}
+
+// Prevents the command from creating a console window.
+func hideProcessWindow(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.HideWindow = true
+	cmd.SysProcAttr.CreationFlags |= createNoWindow
+}
+
+// Asks the process tree rooted at pid to close through taskkill, or kills every process of the tree if forced.
+func signalTree(pid int, tree []*process.Process, force bool) error {
+	if !force {
+		cmd := exec.Command("taskkill", "/T", "/PID", strconv.Itoa(pid))
+		hideProcessWindow(cmd)
+		return cmd.Run()
+	}
+
+	var errorChain error
+	for _, p := range tree {
+		if err := p.Kill(); err != nil && alive(p) {
+			errorChain = errors.Join(errorChain, err)
+		}
+	}
+	return errorChain
+}
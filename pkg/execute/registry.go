@@ -0,0 +1,135 @@
+package execute
+
+import (
+	"driver-box/pkg/status"
+	"errors"
+	"slices"
+	"time"
+)
+
+// Default duration for which exited commands are kept before being evicted.
+const defaultRetention = time.Hour
+
+type State string
+
+const (
+	StatePending State = "pending" // Waiting in the queue, including commands waiting for a retry
+	StateRunning State = "running"
+	StateExited  State = "exited"
+)
+
+// CommandInfo is a snapshot of a command kept by the [CommandExecutor].
+type CommandInfo struct {
+	Id        string        `json:"id"`
+	Program   string        `json:"program"`
+	Options   []string      `json:"options"`
+	State     State         `json:"state"`
+	Status    status.Status `json:"status"` // Final status, empty until the command exits
+	Pid       int           `json:"pid"`
+	StartTime time.Time     `json:"startTime"`
+	ExitTime  time.Time     `json:"exitTime"`
+	Lapse     float32       `json:"lapse"`
+	Attempts  int           `json:"attempts"`
+	Events    []Event       `json:"events"`
+}
+
+// Lists all commands, ordered by the time they were submitted.
+func (ce *CommandExecutor) List() []CommandInfo {
+	ce.evict()
+
+	infos := make([]CommandInfo, 0, ce.commands.Size())
+	ce.commands.Range(func(id string, command *Command) bool {
+		infos = append(infos, command.info(id, ce.queued(id)))
+		return true
+	})
+
+	slices.SortFunc(infos, func(a, b CommandInfo) int {
+		if len(a.Events) == 0 || len(b.Events) == 0 {
+			return len(a.Events) - len(b.Events)
+		}
+		return a.Events[0].Time.Compare(b.Events[0].Time)
+	})
+	return infos
+}
+
+// Returns the state of a command.
+func (ce *CommandExecutor) Inspect(id string) (CommandInfo, error) {
+	if command, ok := ce.commands.Load(id); !ok {
+		return CommandInfo{}, errors.New("execute: id not found")
+	} else {
+		return command.info(id, ce.queued(id)), nil
+	}
+}
+
+// Returns the result of an exited command.
+func (ce *CommandExecutor) Result(id string) (CommandResult, error) {
+	if command, ok := ce.commands.Load(id); !ok {
+		return CommandResult{}, errors.New("execute: id not found")
+	} else if result := command.result.Load(); result == nil {
+		return CommandResult{}, errors.New("execute: command not exited")
+	} else {
+		return *result, nil
+	}
+}
+
+// Removes an exited command from the executor.
+func (ce *CommandExecutor) Evict(id string) error {
+	if command, ok := ce.commands.Load(id); !ok {
+		return errors.New("execute: id not found")
+	} else if command.result.Load() == nil {
+		return errors.New("execute: command not exited")
+	} else {
+		ce.commands.Delete(id)
+		return nil
+	}
+}
+
+// Removes the commands exited longer than the retention period.
+func (ce *CommandExecutor) evict() {
+	retention := ce.Retention
+	if retention <= 0 {
+		retention = defaultRetention
+	}
+
+	ce.commands.Range(func(id string, command *Command) bool {
+		if result := command.result.Load(); result != nil && time.Since(result.ExitTime) > retention {
+			ce.commands.Delete(id)
+		}
+		return true
+	})
+}
+
+// Returns a snapshot of the command, queued telling whether it is waiting in the queue of the executor.
+// A command is running from the time it is dispatched until its result is stored, including between
+// the exit of its process and the evaluation of its result.
+func (t *Command) info(id string, queued bool) CommandInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	info := CommandInfo{
+		Id:        id,
+		Program:   t.cmd.Path,
+		Options:   t.cmd.Args[1:],
+		Pid:       t.pid,
+		StartTime: t.startTime,
+		Attempts:  t.attempts,
+		Events:    append([]Event(nil), t.events...),
+	}
+
+	switch result := t.result.Load(); {
+	case result != nil:
+		info.State = StateExited
+		info.Status = result.Status
+		info.Lapse = result.Lapse
+		info.ExitTime = result.ExitTime
+	case queued, t.attempts == 0:
+		// commands are stored before being queued, and requeued for a retry once their process has exited
+		info.State = StatePending
+	default:
+		info.State = StateRunning
+		if !t.startTime.IsZero() {
+			info.Lapse = float32(time.Since(t.startTime).Milliseconds()) / 1000
+		}
+	}
+	return info
+}
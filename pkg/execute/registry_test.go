@@ -0,0 +1,65 @@
+package execute
+
+import (
+	"testing"
+	"time"
+)
+
+// Waits for the command to reach the state, or fails the test after a few seconds.
+func waitState(t *testing.T, ce *CommandExecutor, id string, state State) CommandInfo {
+	t.Helper()
+
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if info, err := ce.Inspect(id); err != nil {
+			t.Fatal(err)
+		} else if info.State == state {
+			return info
+		}
+	}
+	t.Fatalf("command not %s", state)
+	return CommandInfo{}
+}
+
+func TestInfoState(t *testing.T) {
+	ce := newTestExecutor(time.Minute)
+	ce.Retention = time.Hour
+
+	running := submitScript(t, ce, "running", "sleep 0.5")
+	waitState(t, ce, "running", StateRunning)
+
+	// events recorded while running do not change the state
+	running.record("log", "cannot write the log")
+	if info, _ := ce.Inspect("running"); info.State != StateRunning || info.Pid == 0 {
+		t.Errorf("state after a log event = %s with PID %d, want running", info.State, info.Pid)
+	}
+
+	// a command held back by the concurrency limit is pending
+	ce.pool.mu.Lock()
+	ce.pool.limit = 1
+	ce.pool.mu.Unlock()
+	queued := submitScript(t, ce, "queued", "exit 0")
+	if info, _ := ce.Inspect("queued"); info.State != StatePending {
+		t.Errorf("state of a queued command = %s, want pending", info.State)
+	}
+
+	waitResult(t, running)
+	waitResult(t, queued)
+
+	// events recorded after the exit keep the exit time, so the command is kept for the retention
+	running.record("cleanup", "cannot remove a temporary file")
+	info := waitState(t, ce, "running", StateExited)
+	if info.ExitTime.IsZero() {
+		t.Error("exit time not set")
+	}
+
+	ce.evict()
+	if _, err := ce.Inspect("running"); err != nil {
+		t.Errorf("exited command evicted before the retention: %v", err)
+	}
+
+	ce.Retention = time.Nanosecond
+	ce.evict()
+	if _, err := ce.Inspect("running"); err == nil {
+		t.Error("exited command kept after the retention")
+	}
+}
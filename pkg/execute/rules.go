@@ -0,0 +1,111 @@
+package execute
+
+import (
+	"bufio"
+	"driver-box/pkg/status"
+	"driver-box/pkg/storage"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// OutputMatch records the output rule that determined the status of a command.
+type OutputMatch struct {
+	Rule    int                   `json:"rule"` // Index of the rule in [storage.Driver.OutputRules]
+	Source  string                `json:"source"`
+	Line    string                `json:"line"`
+	Outcome storage.OutputOutcome `json:"outcome"`
+}
+
+// Raw output log of a command, decoded with the encoding of the output in memory.
+type outputLog struct {
+	path     string
+	encoding encoding.Encoding // Detected from the byte order mark if nil
+}
+
+// Matches the rules in order against the output of a command, and returns the first match.
+// Invalid patterns and unreadable log files are reported in errs and skipped.
+// If the path of a stdout or stderr log is given in logs, the log is read in place of the output in memory.
+func matchRules(rules []storage.OutputRule, stdout string, stderr string, logs [2]outputLog) (match *OutputMatch, errs []error) {
+	for i, rule := range rules {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("execute: output rule %d: %w", i, err))
+			continue
+		}
+
+		var (
+			reader io.Reader
+			log    outputLog
+		)
+		switch rule.Source {
+		case "", storage.SourceStdout:
+			reader, log = strings.NewReader(stdout), logs[0]
+		case storage.SourceStderr:
+			reader, log = strings.NewReader(stderr), logs[1]
+		default:
+			log.path = rule.Source
+		}
+
+		if log.path != "" {
+			file, err := os.Open(log.path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("execute: output rule %d: %w", i, err))
+				continue
+			}
+			defer file.Close()
+
+			if log.encoding != nil {
+				reader = transform.NewReader(file, log.encoding.NewDecoder())
+			} else {
+				// log files written by installers are often UTF-16 with a BOM
+				reader = transform.NewReader(file, unicode.BOMOverride(encoding.Nop.NewDecoder()))
+			}
+		}
+
+		if line, ok := matchLine(pattern, reader); ok {
+			return &OutputMatch{Rule: i, Source: rule.Source, Line: line, Outcome: rule.Outcome}, errs
+		}
+	}
+	return nil, errs
+}
+
+// Returns the first line matching the pattern.
+func matchLine(pattern *regexp.Regexp, reader io.Reader) (string, bool) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		if line := strings.TrimRight(scanner.Text(), "\r"); pattern.MatchString(line) {
+			return line, true
+		}
+	}
+	return "", false
+}
+
+// Applies the outcome of an output rule match to the status determined by the exit code.
+func applyMatch(current status.Status, match *OutputMatch) status.Status {
+	if match == nil || current == status.Aborted || current == status.Errored {
+		return current
+	}
+
+	switch match.Outcome {
+	case storage.OutputSuccess:
+		if current == status.RebootRequired {
+			return current
+		}
+		return status.Completed
+	case storage.OutputFailure:
+		return status.Failed
+	case storage.OutputSkipped:
+		return status.Skiped
+	default:
+		return current
+	}
+}
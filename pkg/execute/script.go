@@ -0,0 +1,47 @@
+package execute
+
+import (
+	"driver-box/pkg/storage"
+	"errors"
+	"os"
+	"strings"
+)
+
+// Writes the script into a temporary file, and returns the program and options running it
+// with args passed to the script. The caller is responsible for removing the file.
+func scriptCommand(interpreter storage.Interpreter, script string, args []string) (program string, options []string, path string, err error) {
+	var (
+		pattern string
+		content []byte
+	)
+
+	switch interpreter {
+	case storage.PowerShell:
+		// Windows PowerShell reads scripts without a BOM in the system code page
+		pattern, content = "driver-box-*.ps1", append([]byte{0xEF, 0xBB, 0xBF}, script...)
+		program, options = "powershell", []string{"-NoProfile", "-NonInteractive", "-ExecutionPolicy", "Bypass", "-File"}
+	case storage.Cmd:
+		// labels and multi-line commands of batch files misbehave with LF line endings
+		crlf := strings.ReplaceAll(strings.ReplaceAll(script, "\r\n", "\n"), "\n", "\r\n")
+		pattern, content = "driver-box-*.cmd", []byte(crlf)
+		program, options = "cmd", []string{"/d", "/c"}
+	case storage.Bash:
+		pattern, content = "driver-box-*.sh", []byte(script)
+		program, options = "bash", nil
+	default:
+		return "", nil, "", errors.New("execute: unknown script interpreter")
+	}
+
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", nil, "", err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(content); err != nil {
+		os.Remove(file.Name())
+		return "", nil, "", err
+	}
+
+	return program, append(append(options, file.Name()), args...), file.Name(), nil
+}
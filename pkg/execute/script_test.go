@@ -0,0 +1,90 @@
+package execute
+
+import (
+	"bytes"
+	"driver-box/pkg/status"
+	"driver-box/pkg/storage"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScriptCommand(t *testing.T) {
+	tests := []struct {
+		interpreter storage.Interpreter
+		script      string
+		program     string
+		content     []byte
+	}{
+		{storage.PowerShell, "Write-Output 'é'", "powershell", []byte("\xEF\xBB\xBFWrite-Output 'é'")},
+		{storage.Cmd, "@echo off\n:label\r\necho %1", "cmd", []byte("@echo off\r\n:label\r\necho %1")},
+		{storage.Bash, "echo \"$1\"\n", "bash", []byte("echo \"$1\"\n")},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.interpreter), func(t *testing.T) {
+			program, options, path, err := scriptCommand(tt.interpreter, tt.script, []string{"arg"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(path)
+
+			if program != tt.program {
+				t.Errorf("program = %q, want %q", program, tt.program)
+			}
+			if n := len(options); n < 2 || options[n-2] != path || options[n-1] != "arg" {
+				t.Errorf("options = %q, want the script followed by its arguments", options)
+			}
+			if content, err := os.ReadFile(path); err != nil {
+				t.Fatal(err)
+			} else if !bytes.Equal(content, tt.content) {
+				t.Errorf("script = %q, want %q", content, tt.content)
+			}
+		})
+	}
+
+	if _, _, _, err := scriptCommand("python", "", nil); err == nil {
+		t.Error("scriptCommand() with an unknown interpreter succeeded")
+	}
+}
+
+func TestRunScriptDriver(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not available")
+	}
+
+	ce := newTestExecutor(time.Millisecond)
+	ce.Placeholders = Placeholders{"ROOT": "/root"}
+
+	driver := storage.Driver{
+		Kind:        storage.KindScript,
+		Interpreter: storage.Bash,
+		// the body is not expanded, unlike the flags and environment variables
+		Script:    `echo "${ROOT:-unset} $1 $DRIVER_BOX_DIR"; exit 3`,
+		Flags:     []string{"${ROOT}"},
+		Env:       []string{"DRIVER_BOX_DIR=${ROOT}/drivers"},
+		ExitCodes: map[int32]storage.ExitCodeMeaning{3: storage.ExitReboot},
+	}
+
+	command, err := ce.newDriverCommand(storage.DriverGroup{}, driver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := command.temp[0]
+
+	ce.commands.Store("script", command)
+	ce.submit("script")
+	result := waitResult(t, command)
+
+	if got, want := strings.TrimSpace(result.Stdout), "unset /root /root/drivers"; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+	if result.ExitCode != 3 || result.Status != status.RebootRequired {
+		t.Errorf("exit code %d with status %s, want 3 with %s", result.ExitCode, result.Status, status.RebootRequired)
+	}
+	if _, err := os.Stat(script); !os.IsNotExist(err) {
+		t.Errorf("script %s not removed after the run", script)
+	}
+}
@@ -0,0 +1,74 @@
+package execute
+
+import (
+	"driver-box/pkg/storage"
+	"errors"
+	"strings"
+)
+
+// IDs of the built-in tasks, which are also the IDs drivers refer to in [storage.Driver.Incompatibles].
+const (
+	TaskSetPassword     = "set_password"
+	TaskCreatePartition = "create_partition"
+)
+
+// RunTask submits a built-in task, run as a PowerShell script driver. The password set by
+// [TaskSetPassword] is read from the setting, including the options overridden for the session.
+func (ce *CommandExecutor) RunTask(taskId string) (string, error) {
+	var setting storage.AppSetting
+	if ce.Settings != nil {
+		var err error
+		if setting, err = ce.Settings.Read(); err != nil {
+			return "", err
+		}
+	}
+
+	driver, err := builtinTask(taskId, setting)
+	if err != nil {
+		return "", err
+	}
+
+	command, err := ce.newDriverCommand(storage.DriverGroup{}, driver)
+	if err != nil {
+		return "", err
+	}
+
+	ce.evict()
+
+	id := ce.generateId()
+	ce.commands.Store(id, command)
+
+	ce.submit(id)
+
+	return id, nil
+}
+
+// Returns the script driver of a built-in task.
+func builtinTask(taskId string, setting storage.AppSetting) (storage.Driver, error) {
+	driver := storage.Driver{Id: taskId, Kind: storage.KindScript, Interpreter: storage.PowerShell}
+
+	// errors end the script with a non-zero exit code rather than being printed only
+	const prelude = "$ErrorActionPreference = 'Stop'\r\n"
+
+	switch taskId {
+	case TaskSetPassword:
+		password := "(New-Object System.Security.SecureString)"
+		if setting.Password != "" {
+			password = "(ConvertTo-SecureString " + quotePowerShell(setting.Password) + " -AsPlainText -Force)"
+		}
+		driver.Script = prelude + "Set-LocalUser -Name $Env:UserName -Password " + password
+		driver.MinExeTime = 0.5
+	case TaskCreatePartition:
+		driver.Script = prelude + `Get-Disk | Where-Object PartitionStyle -Eq "RAW" | Initialize-Disk -PassThru | New-Partition -AssignDriveLetter -UseMaximumSize | Format-Volume`
+		driver.MinExeTime = 1
+	default:
+		return storage.Driver{}, errors.New("execute: unknown built-in task")
+	}
+	return driver, nil
+}
+
+// Returns s as a single-quoted PowerShell string, in which nothing is expanded.
+func quotePowerShell(s string) string {
+	// PowerShell also takes the typographic single quotes for quotes
+	return "'" + strings.NewReplacer("'", "''", "‘", "‘‘", "’", "’’", "‚", "‚‚", "‛", "‛‛").Replace(s) + "'"
+}
@@ -0,0 +1,44 @@
+package execute
+
+import (
+	"driver-box/pkg/storage"
+	"strings"
+	"testing"
+)
+
+func TestBuiltinTask(t *testing.T) {
+	tests := []struct {
+		name     string
+		taskId   string
+		password string
+		want     string // Expected in the script
+	}{
+		{"empty password", TaskSetPassword, "", "-Password (New-Object System.Security.SecureString)"},
+		// placeholders and environment variables are not expanded in scripts
+		{"password", TaskSetPassword, "p@ss %PATH% ${ROOT} $x", "(ConvertTo-SecureString 'p@ss %PATH% ${ROOT} $x' -AsPlainText -Force)"},
+		{"quoted password", TaskSetPassword, "it's ‘q’", "'it''s ‘‘q’’'"},
+		{"create partition", TaskCreatePartition, "", "Get-Disk"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := builtinTask(tt.taskId, storage.AppSetting{Password: tt.password})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if driver.Kind != storage.KindScript || driver.Interpreter != storage.PowerShell {
+				t.Errorf("kind %q with interpreter %q, want a PowerShell script", driver.Kind, driver.Interpreter)
+			}
+			if driver.MinExeTime <= 0 {
+				t.Error("no minimum execution time")
+			}
+			if !strings.Contains(driver.Script, tt.want) {
+				t.Errorf("script %q does not contain %q", driver.Script, tt.want)
+			}
+		})
+	}
+
+	if _, err := builtinTask("format_disk", storage.AppSetting{}); err == nil {
+		t.Error("builtinTask() of an unknown task succeeded")
+	}
+}
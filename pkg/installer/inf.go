@@ -0,0 +1,421 @@
+package installer
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// Inf is the information of a driver package read from its INF file.
+type Inf struct {
+	Class     string     `json:"class"`
+	ClassGuid string     `json:"classGuid"`
+	Provider  string     `json:"provider"`
+	Date      string     `json:"date"`    // Date of DriverVer, in mm/dd/yyyy
+	Version   string     `json:"version"` // Version of DriverVer
+	Models    []InfModel `json:"models"`
+}
+
+// InfModel is a device supported by a driver package, declared in a models section.
+type InfModel struct {
+	Manufacturer   string   `json:"manufacturer"`
+	Description    string   `json:"description"`
+	InstallSection string   `json:"installSection"`
+	HardwareIDs    []string `json:"hardwareIds"` // Hardware ID followed by the compatible IDs
+	Arch           string   `json:"arch"`        // Architecture of the decoration of the models section, empty for any
+}
+
+// ParseInfFile parses the INF file at path, see [ParseInf].
+func ParseInfFile(path string) (Inf, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Inf{}, err
+	}
+	defer file.Close()
+
+	return ParseInf(file)
+}
+
+// ParseInf parses the version, manufacturer and models sections of an INF file,
+// with string tokens substituted. UTF-16 and UTF-8 files are recognised by their BOM,
+// other files are read as Windows-1252.
+func ParseInf(r io.Reader) (Inf, error) {
+	sections, err := readInfSections(transform.NewReader(r, unicode.BOMOverride(charmap.Windows1252.NewDecoder())))
+	if err != nil {
+		return Inf{}, err
+	}
+
+	version, ok := sections["version"]
+	if !ok {
+		return Inf{}, errors.New("installer: no version section in the INF file")
+	}
+
+	strs := infStrings(sections)
+	expand := func(s string) string { return expandInfString(s, strs) }
+
+	var inf Inf
+	for _, line := range version {
+		if len(line.values) == 0 {
+			continue
+		}
+		switch strings.ToLower(line.key) {
+		case "class":
+			inf.Class = expand(line.values[0])
+		case "classguid":
+			inf.ClassGuid = strings.ToUpper(line.values[0])
+		case "provider":
+			inf.Provider = expand(line.values[0])
+		case "driverver":
+			inf.Date = line.values[0]
+			if len(line.values) > 1 {
+				inf.Version = line.values[1]
+			}
+		}
+	}
+
+	for _, line := range sections["manufacturer"] {
+		if len(line.values) == 0 {
+			continue
+		}
+		manufacturer := expand(line.key)
+		if manufacturer == "" {
+			manufacturer = line.values[0]
+		}
+
+		for _, models := range modelSections(line.values[0], line.values[1:], sections) {
+			for _, model := range sections[strings.ToLower(models.name)] {
+				if len(model.values) < 2 {
+					continue
+				}
+				ids := slices.DeleteFunc(slices.Clone(model.values[1:]), func(id string) bool { return id == "" })
+				if len(ids) == 0 {
+					continue
+				}
+				inf.Models = append(inf.Models, InfModel{
+					Manufacturer:   manufacturer,
+					Description:    expand(model.key),
+					InstallSection: model.values[0],
+					HardwareIDs:    ids,
+					Arch:           models.arch,
+				})
+			}
+		}
+	}
+	return inf, nil
+}
+
+type infLine struct {
+	key    string // Left-hand side of the line, empty if it has no equals sign
+	values []string
+}
+
+type modelSection struct {
+	name string
+	arch string
+}
+
+// Returns the models sections of a manufacturer entry, which are the name with each of the decorations,
+// and the undecorated name for operating systems not covered by the decorations.
+func modelSections(name string, decorations []string, sections map[string][]infLine) []modelSection {
+	var models []modelSection
+	for _, decoration := range decorations {
+		if decoration == "" {
+			continue
+		}
+		arch, _, _ := strings.Cut(strings.ToLower(decoration), ".")
+		arch = strings.TrimPrefix(arch, "nt")
+		models = append(models, modelSection{name + "." + decoration, arch})
+	}
+	if _, ok := sections[strings.ToLower(name)]; ok || len(models) == 0 {
+		models = append(models, modelSection{name, ""})
+	}
+	return models
+}
+
+// Reads the lines of each section, keyed by the lowercased section names.
+// Comments are removed, and continued lines are joined.
+func readInfSections(r io.Reader) (map[string][]infLine, error) {
+	sections := make(map[string][]infLine)
+	var current string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+
+	var pending string
+	for scanner.Scan() {
+		text := stripInfComment(scanner.Text())
+		if strings.HasSuffix(text, `\`) {
+			pending += strings.TrimSuffix(text, `\`)
+			continue
+		}
+		text, pending = strings.TrimSpace(pending+text), ""
+
+		if text == "" {
+			continue
+		}
+		if strings.HasPrefix(text, "[") {
+			if end := strings.Index(text, "]"); end != -1 {
+				current = strings.ToLower(strings.TrimSpace(text[1:end]))
+				if _, ok := sections[current]; !ok {
+					sections[current] = nil
+				}
+				continue
+			}
+		}
+
+		var line infLine
+		key, value, ok := cutUnquoted(text, '=')
+		if ok {
+			line.key = unquoteInf(strings.TrimSpace(key))
+		} else {
+			value = text
+		}
+		for _, field := range splitUnquoted(value, ',') {
+			line.values = append(line.values, unquoteInf(strings.TrimSpace(field)))
+		}
+		sections[current] = append(sections[current], line)
+	}
+	return sections, scanner.Err()
+}
+
+// Removes the comment starting with a semicolon outside of quotes.
+func stripInfComment(s string) string {
+	quoted := false
+	for i, c := range s {
+		switch c {
+		case '"':
+			quoted = !quoted
+		case ';':
+			if !quoted {
+				return strings.TrimRight(s[:i], " \t")
+			}
+		}
+	}
+	return strings.TrimRight(s, " \t")
+}
+
+func cutUnquoted(s string, sep rune) (string, string, bool) {
+	quoted := false
+	for i, c := range s {
+		if c == '"' {
+			quoted = !quoted
+		} else if c == sep && !quoted {
+			return s[:i], s[i+1:], true
+		}
+	}
+	return s, "", false
+}
+
+func splitUnquoted(s string, sep rune) []string {
+	var fields []string
+	for {
+		field, rest, ok := cutUnquoted(s, sep)
+		fields = append(fields, field)
+		if !ok {
+			return fields
+		}
+		s = rest
+	}
+}
+
+func unquoteInf(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return strings.ReplaceAll(s[1:len(s)-1], `""`, `"`)
+	}
+	return s
+}
+
+// Returns the string tokens, keyed by their lowercased names. Tokens in the [Strings] section
+// take precedence over the localised sections such as [Strings.0409].
+func infStrings(sections map[string][]infLine) map[string]string {
+	strs := make(map[string]string)
+
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		if strings.HasPrefix(name, "strings.") {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	for _, name := range append(names, "strings") {
+		for _, line := range sections[name] {
+			if line.key != "" && len(line.values) > 0 {
+				strs[strings.ToLower(line.key)] = strings.ReplaceAll(strings.Join(line.values, ","), "%%", "%")
+			}
+		}
+	}
+	return strs
+}
+
+// Substitutes %token% with the string tokens, and %% with a percent sign.
+func expandInfString(s string, strs map[string]string) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(s, '%')
+		if start == -1 {
+			break
+		}
+		end := strings.IndexByte(s[start+1:], '%')
+		if end == -1 {
+			break
+		}
+		end += start + 1
+
+		b.WriteString(s[:start])
+		token := s[start+1 : end]
+		if value, ok := strs[strings.ToLower(token)]; ok {
+			b.WriteString(value)
+		} else if token == "" {
+			b.WriteByte('%')
+		} else {
+			b.WriteString(s[start : end+1])
+		}
+		s = s[end+1:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// InfPackage is a driver package found under the driver directory.
+type InfPackage struct {
+	Path     string `json:"path"`
+	Class    string `json:"class"`
+	Provider string `json:"provider"`
+	Date     string `json:"date"`
+	Version  string `json:"version"`
+}
+
+// InfMatch is a driver package supporting a device.
+type InfMatch struct {
+	Package     InfPackage `json:"package"`
+	Description string     `json:"description"`
+	HardwareID  string     `json:"hardwareId"` // ID of the device matched by the package
+	Rank        int        `json:"rank"`       // Position of the matched ID among the IDs of the device, lower is more specific
+}
+
+type infEntry struct {
+	pkg         *InfPackage
+	description string
+	arch        string
+}
+
+// InfIndex maps uppercased hardware and compatible IDs to the driver packages declaring them.
+type InfIndex map[string][]infEntry
+
+// ScanInfs parses every INF file under dir into an index.
+// Files that cannot be parsed are skipped, and their errors are returned along with the index.
+func ScanInfs(dir string) (InfIndex, []error) {
+	index := make(InfIndex)
+
+	var errs []error
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".inf") {
+			return nil
+		}
+
+		inf, err := ParseInfFile(path)
+		if err != nil {
+			errs = append(errs, errors.Join(errors.New("installer: "+path), err))
+			return nil
+		}
+		index.Add(path, inf)
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return index, errs
+}
+
+// Add indexes the models of a parsed INF file at path.
+func (x InfIndex) Add(path string, inf Inf) {
+	pkg := &InfPackage{Path: path, Class: inf.Class, Provider: inf.Provider, Date: inf.Date, Version: inf.Version}
+	for _, model := range inf.Models {
+		for _, id := range model.HardwareIDs {
+			id = strings.ToUpper(id)
+			x[id] = append(x[id], infEntry{pkg, model.Description, model.Arch})
+		}
+	}
+}
+
+// Match returns the packages supporting a device with the given IDs, ordered from the most specific IDs.
+// Models declared for other architectures are left out.
+func (x InfIndex) Match(ids []string) []InfMatch {
+	var matches []InfMatch
+	for rank, id := range ids {
+		for _, entry := range x[strings.ToUpper(id)] {
+			if entry.arch != "" && entry.arch != osArch() {
+				continue
+			}
+			if slices.ContainsFunc(matches, func(m InfMatch) bool { return m.Package.Path == entry.pkg.Path }) {
+				continue
+			}
+			matches = append(matches, InfMatch{*entry.pkg, entry.description, id, rank})
+		}
+	}
+	return matches
+}
+
+// Architecture of the operating system as named by INF decorations, which differs from that of the program
+// when a 32-bit build runs on a 64-bit system.
+var osArch = sync.OnceValue(hostArch)
+
+// Returns the architecture of a Go build as named by INF decorations.
+func goArch(arch string) string {
+	switch arch {
+	case "386":
+		return "x86"
+	default:
+		return arch
+	}
+}
+
+// DeviceIDs derives the hardware IDs of a device from its instance ID, from the most to the least specific.
+// The IDs keep the enumerator and the first two fields, e.g. PCI\VEN_8086&DEV_15B8&SUBSYS_86721043&REV_31\3&11583659&0&FE
+// gives PCI\VEN_8086&DEV_15B8&SUBSYS_86721043&REV_31, PCI\VEN_8086&DEV_15B8&SUBSYS_86721043,
+// PCI\VEN_8086&DEV_15B8&REV_31 and PCI\VEN_8086&DEV_15B8.
+func DeviceIDs(instanceID string) []string {
+	parts := strings.Split(strings.ToUpper(instanceID), `\`)
+	if len(parts) < 2 {
+		return nil
+	}
+	enumerator, fields := parts[0], strings.Split(parts[1], "&")
+	if len(fields) <= 2 {
+		return []string{enumerator + `\` + parts[1]}
+	}
+
+	// every subset of the optional fields, keeping their order, from the largest
+	optional := fields[2:min(len(fields), 6)]
+	var subsets [][]string
+	for mask := 1<<len(optional) - 1; mask >= 0; mask-- {
+		var subset []string
+		for i, field := range optional {
+			if mask&(1<<(len(optional)-1-i)) != 0 {
+				subset = append(subset, field)
+			}
+		}
+		subsets = append(subsets, subset)
+	}
+	slices.SortStableFunc(subsets, func(a, b []string) int { return len(b) - len(a) })
+
+	ids := make([]string, 0, len(subsets))
+	for _, subset := range subsets {
+		ids = append(ids, enumerator+`\`+strings.Join(append(slices.Clone(fields[:2]), subset...), "&"))
+	}
+	return ids
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package installer
+
+import "runtime"
+
+func hostArch() string {
+	return goArch(runtime.GOARCH)
+}
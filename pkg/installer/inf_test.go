@@ -0,0 +1,106 @@
+package installer
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/encoding/unicode"
+)
+
+var sampleInf = Inf{
+	Class:     "Net",
+	ClassGuid: "{4D36E972-E325-11CE-BFC1-08002BE10318}",
+	Provider:  "Intel",
+	Date:      "05/14/2024",
+	Version:   "12.19.2.45",
+	Models: []InfModel{
+		{"Intel Corporation", "Intel(R) Ethernet Connection (2) I219-V", "E15B8.ndi",
+			[]string{`PCI\VEN_8086&DEV_15B8&SUBSYS_86721043`, `PCI\VEN_8086&DEV_15B8`}, "amd64"},
+		{"Intel Corporation", "Intel(R) Ethernet Connection (2) I219-LM, 100% Gigabit", "E15B9.ndi",
+			[]string{`PCI\VEN_8086&DEV_15B9`}, "amd64"},
+		{"Intel Corporation", "Intel(R) Ethernet Connection (2) I219-V", "E15B8.ndi.x86",
+			[]string{`PCI\VEN_8086&DEV_15B8`}, "x86"},
+		{"Intel Corporation", "Intel(R) Ethernet Connection (2) I219-V", "E15B8.ndi.arm64",
+			[]string{`PCI\VEN_8086&DEV_15B8`}, "arm64"},
+		{"Contoso", `Contoso "Fast" Ethernet`, "Contoso.ndi",
+			[]string{`PCI\VEN_1234&DEV_0001`}, ""},
+	},
+}
+
+func TestParseInf(t *testing.T) {
+	raw, err := os.ReadFile("testdata/sample.inf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	utf16, err := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().Bytes(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		raw  []byte
+	}{
+		{"ANSI", raw},
+		{"UTF-8", append([]byte{0xEF, 0xBB, 0xBF}, raw...)},
+		{"UTF-16", utf16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inf, err := ParseInf(bytes.NewReader(tt.raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(inf, sampleInf) {
+				t.Errorf("ParseInf() = %+v, want %+v", inf, sampleInf)
+			}
+		})
+	}
+
+	if _, err := ParseInf(bytes.NewReader([]byte("[Strings]\r\nName = Value\r\n"))); err == nil {
+		t.Error("ParseInf() without a version section succeeded")
+	}
+}
+
+func TestInfIndexMatch(t *testing.T) {
+	index := make(InfIndex)
+	index.Add(`C:\drivers\sample.inf`, sampleInf)
+	ids := DeviceIDs(`PCI\VEN_8086&DEV_15B8&SUBSYS_86721043&REV_31\3&11583659&0&FE`)
+
+	defer func(arch func() string) { osArch = arch }(osArch)
+
+	tests := []struct {
+		name     string
+		arch     string
+		ids      []string
+		wantID   string // ID of the match, empty if none
+		wantRank int
+	}{
+		{"specific ID", "amd64", ids, `PCI\VEN_8086&DEV_15B8&SUBSYS_86721043`, 1},
+		{"other architecture", "x86", ids, `PCI\VEN_8086&DEV_15B8`, 3},
+		{"no model", "arm", ids, "", 0},
+		{"undecorated", "arm", []string{`pci\ven_1234&dev_0001`}, `pci\ven_1234&dev_0001`, 0},
+		{"unknown device", "amd64", []string{`PCI\VEN_10EC&DEV_8168`}, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			osArch = func() string { return tt.arch }
+
+			matches := index.Match(tt.ids)
+			if tt.wantID == "" {
+				if len(matches) != 0 {
+					t.Errorf("Match() = %+v, want none", matches)
+				}
+				return
+			}
+			if len(matches) != 1 {
+				t.Fatalf("Match() = %+v, want one match", matches)
+			}
+			if m := matches[0]; m.HardwareID != tt.wantID || m.Rank != tt.wantRank || m.Package.Version != "12.19.2.45" {
+				t.Errorf("Match() = %+v, want %s at rank %d", m, tt.wantID, tt.wantRank)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package installer
+
+import (
+	"debug/pe"
+	"os"
+	"runtime"
+	"strings"
+
+	"golang.org/x/sys/windows"
+)
+
+// Returns the native architecture of the system, see [osArch].
+func hostArch() string {
+	var process, native uint16
+	if err := windows.IsWow64Process2(windows.CurrentProcess(), &process, &native); err == nil {
+		switch native {
+		case pe.IMAGE_FILE_MACHINE_I386:
+			return "x86"
+		case pe.IMAGE_FILE_MACHINE_AMD64:
+			return "amd64"
+		case pe.IMAGE_FILE_MACHINE_ARM64:
+			return "arm64"
+		case pe.IMAGE_FILE_MACHINE_ARMNT:
+			return "arm"
+		}
+	}
+
+	// IsWow64Process2 is missing before Windows 10 1511, where a 32-bit process on a 64-bit system
+	// is given the native architecture by the environment, e.g. AMD64
+	if arch := os.Getenv("PROCESSOR_ARCHITEW6432"); arch != "" {
+		return strings.ToLower(arch)
+	}
+	return goArch(runtime.GOARCH)
+}
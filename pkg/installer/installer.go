@@ -0,0 +1,166 @@
+package installer
+
+import (
+	"bytes"
+	"debug/pe"
+	"driver-box/pkg/storage"
+	"errors"
+	"io"
+	"os"
+	"unicode/utf16"
+)
+
+type Type string
+
+const (
+	Unknown       Type = "unknown"
+	MSI           Type = "msi"
+	NSIS          Type = "nsis"
+	InnoSetup     Type = "inno_setup"
+	InstallShield Type = "installshield"
+	WixBurn       Type = "wix_burn"
+	SevenZipSfx   Type = "7z_sfx"
+)
+
+// Analysis describes the detected type of an installer, and the driver settings suggested to run it silently.
+type Analysis struct {
+	Type      Type                              `json:"type"`
+	Kind      storage.DriverKind                `json:"kind"`
+	Flags     []string                          `json:"flags"`
+	ExitCodes map[int32]storage.ExitCodeMeaning `json:"exitCodes"`
+	Evidence  string                            `json:"evidence"` // Signature the type was detected by
+}
+
+const (
+	headSize    = 4 << 20  // Bytes scanned from the start of the file, covering the headers and resources
+	overlaySize = 64 << 10 // Bytes scanned from the start of the data appended after the PE sections
+)
+
+var (
+	oleMagic      = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+	nsisMagic     = []byte("\xEF\xBE\xAD\xDENullsoftInst")
+	sevenZipMagic = []byte{'7', 'z', 0xBC, 0xAF, 0x27, 0x1C}
+)
+
+// AnalyzeFile detects the type of the installer at path, see [Analyze].
+func AnalyzeFile(path string) (Analysis, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Analysis{}, err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return Analysis{}, err
+	}
+	return Analyze(file, stat.Size())
+}
+
+// Analyze detects the type of an installer of the given size by its PE sections and byte signatures.
+// An analysis of [Unknown] type is returned for executables not created by a known installer framework.
+func Analyze(r io.ReaderAt, size int64) (Analysis, error) {
+	head := make([]byte, min(size, headSize))
+	if _, err := r.ReadAt(head, 0); err != nil && err != io.EOF {
+		return Analysis{}, err
+	}
+
+	if bytes.HasPrefix(head, oleMagic) {
+		return suggest(MSI, "compound file header"), nil
+	}
+
+	file, err := pe.NewFile(r)
+	if err != nil {
+		return Analysis{}, errors.Join(errors.New("installer: neither a PE file nor an MSI package"), err)
+	}
+	defer file.Close()
+
+	var overlayStart int64
+	for _, section := range file.Sections {
+		if section.Name == ".wixburn" {
+			return suggest(WixBurn, ".wixburn section"), nil
+		}
+		overlayStart = max(overlayStart, int64(section.Offset)+int64(section.Size))
+	}
+
+	var overlay []byte
+	if overlayStart < size {
+		overlay = make([]byte, min(size-overlayStart, overlaySize))
+		if _, err := r.ReadAt(overlay, overlayStart); err != nil && err != io.EOF {
+			return Analysis{}, err
+		}
+	}
+
+	switch {
+	case contains(head, "Inno Setup Setup Data") || contains(overlay, "Inno Setup Setup Data"):
+		return suggest(InnoSetup, "setup data header"), nil
+	case bytes.Contains(head, []byte("rDlPtS")) || contains(head, "JR.Inno.Setup"):
+		return suggest(InnoSetup, "setup loader"), nil
+	case bytes.Contains(overlay, nsisMagic) || bytes.Contains(head, nsisMagic):
+		return suggest(NSIS, "NullsoftInst header"), nil
+	case contains(head, "Nullsoft.NSIS.exehead"):
+		return suggest(NSIS, "manifest"), nil
+	case contains(head, "InstallShield"):
+		return suggest(InstallShield, "version information"), nil
+	case bytes.HasPrefix(overlay, sevenZipMagic) || bytes.Contains(overlay, []byte(";!@Install@!UTF-8!")):
+		return suggest(SevenZipSfx, "7z archive appended"), nil
+	default:
+		return suggest(Unknown, ""), nil
+	}
+}
+
+// Returns the suggested settings of an installer type.
+func suggest(t Type, evidence string) Analysis {
+	analysis := Analysis{Type: t, Kind: storage.KindExecutable, Evidence: evidence}
+
+	switch t {
+	case MSI:
+		// the command line is built by the driver kind
+		analysis.Kind = storage.KindMsi
+		analysis.ExitCodes = storage.MsiExitCodes
+	case NSIS:
+		analysis.Flags = []string{"/S"}
+		analysis.ExitCodes = map[int32]storage.ExitCodeMeaning{
+			0: storage.ExitSuccess,
+			1: storage.ExitFatal, // cancelled by the user
+			2: storage.ExitFatal, // aborted by the script
+		}
+	case InnoSetup:
+		analysis.Flags = []string{"/VERYSILENT", "/SUPPRESSMSGBOXES", "/NORESTART", "/SP-"}
+		analysis.ExitCodes = map[int32]storage.ExitCodeMeaning{
+			0: storage.ExitSuccess,
+			1: storage.ExitFatal,  // failed to initialise
+			2: storage.ExitFatal,  // cancelled before the installation started
+			3: storage.ExitFatal,  // fatal error while preparing
+			4: storage.ExitFatal,  // fatal error during the installation
+			5: storage.ExitFatal,  // cancelled during the installation
+			6: storage.ExitFatal,  // forcefully terminated
+			7: storage.ExitFatal,  // preparing to install failed
+			8: storage.ExitReboot, // a restart is required before installing
+		}
+	case InstallShield:
+		// flags after /v are passed to the embedded Windows Installer package
+		analysis.Flags = []string{"/s", "/v/qn"}
+		analysis.ExitCodes = storage.MsiExitCodes
+	case WixBurn:
+		analysis.Flags = []string{"/quiet", "/norestart"}
+		analysis.ExitCodes = storage.MsiExitCodes
+	case SevenZipSfx:
+		analysis.Flags = []string{"-y"}
+		analysis.ExitCodes = map[int32]storage.ExitCodeMeaning{0: storage.ExitSuccess}
+	}
+	return analysis
+}
+
+// Reports whether b contains s in ASCII or UTF-16LE, the encoding of PE resources.
+func contains(b []byte, s string) bool {
+	if bytes.Contains(b, []byte(s)) {
+		return true
+	}
+
+	wide := make([]byte, 0, len(s)*2)
+	for _, c := range utf16.Encode([]rune(s)) {
+		wide = append(wide, byte(c), byte(c>>8))
+	}
+	return bytes.Contains(b, wide)
+}
@@ -0,0 +1,214 @@
+package installer
+
+import (
+	"bytes"
+	"debug/pe"
+	"driver-box/pkg/storage"
+	"encoding/binary"
+	"testing"
+	"unicode/utf16"
+)
+
+// Section of a synthesized executable, see [buildPE].
+type peSection struct {
+	name string
+	data []byte
+}
+
+const (
+	peFileAlignment    = 0x200
+	peSectionAlignment = 0x1000
+)
+
+// Returns the virtual address of the i-th section of an executable built by [buildPE].
+func sectionRVA(i int) uint32 {
+	return uint32(i+1) * peSectionAlignment
+}
+
+// Builds a minimal 32-bit executable with the sections, followed by the overlay. The resource
+// data directory points at the section named .rsrc, if any.
+func buildPE(t *testing.T, sections []peSection, overlay []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	write := func(v any) {
+		if err := binary.Write(&buf, binary.LittleEndian, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pad := func(alignment int) {
+		buf.Write(make([]byte, (alignment-buf.Len()%alignment)%alignment))
+	}
+
+	// DOS header, of which only the magic and the offset of the PE header matter
+	dos := make([]byte, 0x40)
+	copy(dos, "MZ")
+	binary.LittleEndian.PutUint32(dos[0x3C:], 0x40)
+	buf.Write(dos)
+	buf.WriteString("PE\x00\x00")
+
+	write(pe.FileHeader{
+		Machine:              pe.IMAGE_FILE_MACHINE_I386,
+		NumberOfSections:     uint16(len(sections)),
+		SizeOfOptionalHeader: uint16(binary.Size(pe.OptionalHeader32{})),
+		Characteristics:      pe.IMAGE_FILE_EXECUTABLE_IMAGE | pe.IMAGE_FILE_32BIT_MACHINE,
+	})
+
+	optional := pe.OptionalHeader32{
+		Magic:               0x10B,
+		SectionAlignment:    peSectionAlignment,
+		FileAlignment:       peFileAlignment,
+		SizeOfImage:         sectionRVA(len(sections)),
+		NumberOfRvaAndSizes: 16,
+	}
+	for i, section := range sections {
+		if section.name == ".rsrc" {
+			optional.DataDirectory[pe.IMAGE_DIRECTORY_ENTRY_RESOURCE] = pe.DataDirectory{
+				VirtualAddress: sectionRVA(i),
+				Size:           uint32(len(section.data)),
+			}
+		}
+	}
+	write(optional)
+
+	headersSize := buf.Len() + len(sections)*binary.Size(pe.SectionHeader32{})
+	offset := uint32((headersSize + peFileAlignment - 1) &^ (peFileAlignment - 1))
+	for i, section := range sections {
+		header := pe.SectionHeader32{
+			VirtualSize:      uint32(len(section.data)),
+			VirtualAddress:   sectionRVA(i),
+			SizeOfRawData:    uint32((len(section.data) + peFileAlignment - 1) &^ (peFileAlignment - 1)),
+			PointerToRawData: offset,
+		}
+		copy(header.Name[:], section.name)
+		write(header)
+		offset += header.SizeOfRawData
+	}
+
+	for _, section := range sections {
+		pad(peFileAlignment)
+		buf.Write(section.data)
+	}
+	pad(peFileAlignment)
+
+	buf.Write(overlay)
+	return buf.Bytes()
+}
+
+// Encodes s in UTF-16LE, the encoding of PE resources.
+func utf16le(s string) []byte {
+	var b []byte
+	for _, c := range utf16.Encode([]rune(s)) {
+		b = binary.LittleEndian.AppendUint16(b, c)
+	}
+	return b
+}
+
+func TestAnalyze(t *testing.T) {
+	code := peSection{".text", bytes.Repeat([]byte{0xCC}, 64)}
+
+	tests := []struct {
+		name     string
+		file     []byte
+		want     Type
+		evidence string
+	}{
+		{
+			name:     "msi",
+			file:     append(bytes.Clone(oleMagic), make([]byte, 512)...),
+			want:     MSI,
+			evidence: "compound file header",
+		},
+		{
+			// the first header of the NSIS data starts with its flags, followed by the magic
+			name:     "nsis",
+			file:     buildPE(t, []peSection{code}, append([]byte{0, 0, 0, 0}, nsisMagic...)),
+			want:     NSIS,
+			evidence: "NullsoftInst header",
+		},
+		{
+			name:     "nsis manifest",
+			file:     buildPE(t, []peSection{code, {".rsrc", []byte(`<assemblyIdentity name="Nullsoft.NSIS.exehead"/>`)}}, nil),
+			want:     NSIS,
+			evidence: "manifest",
+		},
+		{
+			name:     "inno setup data",
+			file:     buildPE(t, []peSection{code}, []byte("Inno Setup Setup Data (6.2.2) (u)")),
+			want:     InnoSetup,
+			evidence: "setup data header",
+		},
+		{
+			name:     "inno setup loader",
+			file:     buildPE(t, []peSection{code, {".rsrc", append([]byte("rDlPtS\xCD\xE6\xD7\x7B\x0B\x2A"), make([]byte, 32)...)}}, nil),
+			want:     InnoSetup,
+			evidence: "setup loader",
+		},
+		{
+			name:     "installshield",
+			file:     buildPE(t, []peSection{code, {".rsrc", utf16le("InstallShield Software Corporation")}}, nil),
+			want:     InstallShield,
+			evidence: "version information",
+		},
+		{
+			name:     "wix burn",
+			file:     buildPE(t, []peSection{code, {".wixburn", make([]byte, 52)}}, nil),
+			want:     WixBurn,
+			evidence: ".wixburn section",
+		},
+		{
+			name:     "7-zip sfx",
+			file:     buildPE(t, []peSection{code}, append(bytes.Clone(sevenZipMagic), 0, 4)),
+			want:     SevenZipSfx,
+			evidence: "7z archive appended",
+		},
+		{
+			name:     "7-zip sfx configuration",
+			file:     buildPE(t, []peSection{code}, []byte(";!@Install@!UTF-8!\r\nRunProgram=\"setup.exe\"\r\n;!@InstallEnd@!")),
+			want:     SevenZipSfx,
+			evidence: "7z archive appended",
+		},
+		{
+			name: "plain executable",
+			file: buildPE(t, []peSection{code}, nil),
+			want: Unknown,
+		},
+		{
+			// not an installer, see testdata/README.md
+			name: "signed executable",
+			file: readSigned(t),
+			want: Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis, err := Analyze(bytes.NewReader(tt.file), int64(len(tt.file)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if analysis.Type != tt.want || analysis.Evidence != tt.evidence {
+				t.Errorf("Analyze() = %s by %q, want %s by %q", analysis.Type, analysis.Evidence, tt.want, tt.evidence)
+			}
+
+			switch tt.want {
+			case Unknown:
+				if len(analysis.Flags) > 0 || analysis.Kind != storage.KindExecutable {
+					t.Errorf("suggested %s with flags %q for an unknown executable", analysis.Kind, analysis.Flags)
+				}
+			case MSI:
+				if analysis.Kind != storage.KindMsi {
+					t.Errorf("kind = %s, want %s", analysis.Kind, storage.KindMsi)
+				}
+			default:
+				if len(analysis.Flags) == 0 || analysis.ExitCodes[0] != storage.ExitSuccess {
+					t.Errorf("suggested flags %q and exit codes %v, want silent flags", analysis.Flags, analysis.ExitCodes)
+				}
+			}
+		})
+	}
+
+	if _, err := Analyze(bytes.NewReader([]byte("not an installer")), 16); err == nil {
+		t.Error("Analyze() of a text file succeeded")
+	}
+}
@@ -0,0 +1,62 @@
+package installer
+
+import (
+	"driver-box/pkg/storage"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// MetadataReader reads driver metadata from the version information of the files at the paths of drivers.
+type MetadataReader struct {
+	Expand func(string) string // Expands placeholders in the paths, if any
+	Root   string              // Directory relative paths are resolved against
+}
+
+// ReadMetadata implements [storage.MetadataReader]. MSI packages are read from their Property table.
+// Files without version information, such as INF files, only have their modification time and size recorded.
+func (m MetadataReader) ReadMetadata(driver storage.Driver) (storage.DriverMetadata, error) {
+	if driver.Kind == storage.KindScript || driver.Path == "" {
+		return storage.DriverMetadata{}, nil
+	}
+
+	path := m.Resolve(driver.Path)
+	stat, err := os.Stat(path)
+	if err != nil {
+		return driver.Metadata, err
+	}
+	if stat.IsDir() {
+		return driver.Metadata, errors.New("installer: path is a directory")
+	}
+
+	// packages recorded before their Property table was read have no product name
+	current := driver.Metadata
+	if current.Size == stat.Size() && current.ModTime.Equal(stat.ModTime()) && (driver.Kind != storage.KindMsi || current.ProductName != "") {
+		return current, nil
+	}
+
+	read := ReadVersionInfoFile
+	if driver.Kind == storage.KindMsi {
+		read = ReadMsiInfoFile
+	}
+
+	metadata := storage.DriverMetadata{ModTime: stat.ModTime(), Size: stat.Size()}
+	if info, err := read(path); err == nil {
+		metadata.ProductName = info.ProductName
+		metadata.CompanyName = info.CompanyName
+		metadata.FileVersion = info.FileVersion
+		metadata.ProductVersion = info.ProductVersion
+	}
+	return metadata, nil
+}
+
+// Resolve expands the placeholders in a driver path, and resolves it against the root directory if relative.
+func (m MetadataReader) Resolve(path string) string {
+	if m.Expand != nil {
+		path = m.Expand(path)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(m.Root, path)
+	}
+	return path
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package installer
+
+import "errors"
+
+// ReadMsiInfoFile reads the Property table of a Windows Installer package, which requires msi.dll.
+func ReadMsiInfoFile(path string) (VersionInfo, error) {
+	return VersionInfo{}, errors.New("installer: Windows Installer packages can only be read on Windows")
+}
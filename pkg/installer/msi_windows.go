@@ -0,0 +1,105 @@
+package installer
+
+import (
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	modmsi                  = windows.NewLazySystemDLL("msi.dll")
+	procMsiOpenDatabase     = modmsi.NewProc("MsiOpenDatabaseW")
+	procMsiDatabaseOpenView = modmsi.NewProc("MsiDatabaseOpenViewW")
+	procMsiViewExecute      = modmsi.NewProc("MsiViewExecute")
+	procMsiViewFetch        = modmsi.NewProc("MsiViewFetch")
+	procMsiRecordGetString  = modmsi.NewProc("MsiRecordGetStringW")
+	procMsiCloseHandle      = modmsi.NewProc("MsiCloseHandle")
+)
+
+// ReadMsiInfoFile reads the product name, manufacturer and product version from the Property table
+// of the Windows Installer package at path. Packages have no file version.
+func ReadMsiInfoFile(path string) (VersionInfo, error) {
+	properties, err := readMsiProperties(path)
+	if err != nil {
+		return VersionInfo{}, err
+	}
+	return VersionInfo{
+		ProductName:    properties["ProductName"],
+		CompanyName:    properties["Manufacturer"],
+		ProductVersion: properties["ProductVersion"],
+	}, nil
+}
+
+// Returns the Property table of the package at path.
+func readMsiProperties(path string) (map[string]string, error) {
+	name, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	query, _ := windows.UTF16PtrFromString("SELECT `Property`, `Value` FROM `Property`")
+
+	// the database is opened read-only by a nil persist mode
+	var database, view uint32
+	if err := msiCall(procMsiOpenDatabase, uintptr(unsafe.Pointer(name)), 0, uintptr(unsafe.Pointer(&database))); err != nil {
+		return nil, err
+	}
+	defer procMsiCloseHandle.Call(uintptr(database))
+
+	if err := msiCall(procMsiDatabaseOpenView, uintptr(database), uintptr(unsafe.Pointer(query)), uintptr(unsafe.Pointer(&view))); err != nil {
+		return nil, err
+	}
+	defer procMsiCloseHandle.Call(uintptr(view))
+
+	if err := msiCall(procMsiViewExecute, uintptr(view), 0); err != nil {
+		return nil, err
+	}
+
+	properties := make(map[string]string)
+	for {
+		var record uint32
+		if err := msiCall(procMsiViewFetch, uintptr(view), uintptr(unsafe.Pointer(&record))); err == windows.ERROR_NO_MORE_ITEMS {
+			return properties, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		key, err := msiRecordString(record, 1)
+		if err == nil {
+			properties[key], err = msiRecordString(record, 2)
+		}
+		procMsiCloseHandle.Call(uintptr(record))
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Returns the string in a field of a record, growing the buffer until it fits.
+func msiRecordString(record uint32, field uint32) (string, error) {
+	buf := make([]uint16, 256)
+	for {
+		size := uint32(len(buf))
+		err := msiCall(procMsiRecordGetString, uintptr(record), uintptr(field), uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size)))
+		if err == windows.ERROR_MORE_DATA {
+			// the size excludes the terminating null character
+			buf = make([]uint16, size+1)
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return windows.UTF16ToString(buf[:size]), nil
+	}
+}
+
+// Calls a function of msi.dll, which returns a Windows error code.
+func msiCall(proc *windows.LazyProc, args ...uintptr) error {
+	if err := proc.Find(); err != nil {
+		return err
+	}
+	if r, _, _ := proc.Call(args...); r != 0 {
+		return syscall.Errno(r)
+	}
+	return nil
+}
@@ -0,0 +1,521 @@
+package installer
+
+import (
+	"bytes"
+	"cmp"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"debug/pe"
+	"driver-box/pkg/storage"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	_ "crypto/md5"
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// Signature describes the Authenticode signature of an executable.
+type Signature struct {
+	Signed          bool      `json:"signed"`
+	Subject         string    `json:"subject"`      // Subject of the signing certificate
+	CommonName      string    `json:"commonName"`   // Common name in the subject of the signing certificate
+	Organization    []string  `json:"organization"` // Organizations in the subject of the signing certificate
+	Issuer          string    `json:"issuer"`
+	Timestamp       time.Time `json:"timestamp"` // Time given by the countersignature, zero if not timestamped or not verified
+	DigestAlgorithm string    `json:"digestAlgorithm"`
+	Digest          string    `json:"digest"`  // Hex encoded digest of the file as signed
+	Valid           bool      `json:"valid"`   // Whether the file matches the digest and the digest is signed by the certificate
+	Trusted         bool      `json:"trusted"` // Whether the certificate chains to a trusted root for code signing
+	Error           string    `json:"error"`   // Reason of an invalid or untrusted signature
+}
+
+var (
+	oidSignedData             = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidMessageDigest          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidSigningTime            = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+	oidCounterSignature       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 6}
+	oidTimestampToken         = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 3, 3, 1}
+	oidSpcIndirectDataContent = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 2, 1, 4}
+)
+
+// Hash functions by the OID of their algorithm identifiers.
+var digestAlgorithms = []struct {
+	oid  asn1.ObjectIdentifier
+	hash crypto.Hash
+	name string
+}{
+	{asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 5}, crypto.MD5, "MD5"},
+	{asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}, crypto.SHA1, "SHA-1"},
+	{asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}, crypto.SHA256, "SHA-256"},
+	{asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}, crypto.SHA384, "SHA-384"},
+	{asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}, crypto.SHA512, "SHA-512"},
+}
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,optional,tag:0"`
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	ContentInfo      contentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+type signerInfo struct {
+	Version                   int
+	IssuerAndSerialNumber     issuerAndSerialNumber
+	DigestAlgorithm           pkix.AlgorithmIdentifier
+	AuthenticatedAttributes   asn1.RawValue `asn1:"optional,tag:0"`
+	DigestEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedDigest           []byte
+	UnauthenticatedAttributes asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type issuerAndSerialNumber struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue `asn1:"set"`
+}
+
+type spcIndirectDataContent struct {
+	Data          asn1.RawValue
+	MessageDigest digestInfo
+}
+
+type digestInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	Digest    []byte
+}
+
+type tstInfo struct {
+	Version        int
+	Policy         asn1.ObjectIdentifier
+	MessageImprint digestInfo
+	SerialNumber   *big.Int
+	GenTime        time.Time `asn1:"generalized"`
+}
+
+// InspectSignatureFile inspects the Authenticode signature of the executable at path, see [InspectSignature].
+func InspectSignatureFile(path string) (Signature, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Signature{}, err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return Signature{}, err
+	}
+	return InspectSignature(file, stat.Size())
+}
+
+// InspectSignature reads the primary Authenticode signature in the certificate table of an executable,
+// and checks the digest of the file against it. An error is only returned if the file is not an executable;
+// problems of the signature itself are reported in [Signature.Error].
+func InspectSignature(r io.ReaderAt, size int64) (Signature, error) {
+	file, err := pe.NewFile(r)
+	if err != nil {
+		return Signature{}, err
+	}
+	defer file.Close()
+
+	var (
+		security pe.DataDirectory
+		dirAt    int64 // File offset of the security data directory entry
+	)
+
+	var header [4]byte
+	if _, err := r.ReadAt(header[:], 0x3C); err != nil {
+		return Signature{}, err
+	}
+	optionalAt := int64(binary.LittleEndian.Uint32(header[:])) + 4 + 20
+
+	switch h := file.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		if len(h.DataDirectory) > pe.IMAGE_DIRECTORY_ENTRY_SECURITY {
+			security = h.DataDirectory[pe.IMAGE_DIRECTORY_ENTRY_SECURITY]
+		}
+		dirAt = optionalAt + 96 + 8*pe.IMAGE_DIRECTORY_ENTRY_SECURITY
+	case *pe.OptionalHeader64:
+		if len(h.DataDirectory) > pe.IMAGE_DIRECTORY_ENTRY_SECURITY {
+			security = h.DataDirectory[pe.IMAGE_DIRECTORY_ENTRY_SECURITY]
+		}
+		dirAt = optionalAt + 112 + 8*pe.IMAGE_DIRECTORY_ENTRY_SECURITY
+	default:
+		return Signature{}, errors.New("installer: no optional header")
+	}
+
+	// the virtual address of the security directory is a file offset
+	tableAt, tableSize := int64(security.VirtualAddress), int64(security.Size)
+	if tableAt == 0 || tableSize < 8 {
+		return Signature{}, nil
+	}
+
+	sig := Signature{Signed: true}
+	if tableAt+tableSize > size {
+		sig.Error = "certificate table out of bounds"
+		return sig, nil
+	}
+
+	table := make([]byte, tableSize)
+	if _, err := r.ReadAt(table, tableAt); err != nil {
+		return Signature{}, err
+	}
+
+	// the first WIN_CERTIFICATE holds the primary signature
+	length := int(binary.LittleEndian.Uint32(table))
+	if length < 8 || length > len(table) || binary.LittleEndian.Uint16(table[6:]) != 2 {
+		sig.Error = "no PKCS#7 signed data in the certificate table"
+		return sig, nil
+	}
+
+	if err := parseSignature(&sig, table[8:length], func(hash crypto.Hash) ([]byte, error) {
+		// checksum and security directory entry are excluded, along with the certificate table
+		return imageDigest(r, size, hash, [][2]int64{
+			{optionalAt + 64, 4},
+			{dirAt, 8},
+			{tableAt, tableSize},
+		})
+	}); err != nil {
+		sig.Error = err.Error()
+	}
+	return sig, nil
+}
+
+// Parses a PKCS#7 signed data into sig, and verifies it against the digest of the file given by digest.
+func parseSignature(sig *Signature, der []byte, digest func(crypto.Hash) ([]byte, error)) error {
+	var info contentInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return err
+	}
+	if !info.ContentType.Equal(oidSignedData) {
+		return errors.New("not a PKCS#7 signed data")
+	}
+
+	var signed signedData
+	if _, err := asn1.Unmarshal(info.Content.Bytes, &signed); err != nil {
+		return err
+	}
+	if !signed.ContentInfo.ContentType.Equal(oidSpcIndirectDataContent) || len(signed.SignerInfos) == 0 {
+		return errors.New("not an Authenticode signature")
+	}
+
+	// the content is explicitly tagged, the digest of the signer covers the value of the inner sequence
+	var (
+		content  asn1.RawValue
+		indirect spcIndirectDataContent
+	)
+	if _, err := asn1.Unmarshal(signed.ContentInfo.Content.Bytes, &content); err != nil {
+		return err
+	}
+	if _, err := asn1.Unmarshal(content.FullBytes, &indirect); err != nil {
+		return err
+	}
+
+	hash, name, ok := lookupDigest(indirect.MessageDigest.Algorithm.Algorithm)
+	if !ok {
+		return fmt.Errorf("unsupported digest algorithm %s", indirect.MessageDigest.Algorithm.Algorithm)
+	}
+	sig.DigestAlgorithm = name
+	sig.Digest = hex.EncodeToString(indirect.MessageDigest.Digest)
+
+	certs, err := x509.ParseCertificates(signed.Certificates.Bytes)
+	if err != nil {
+		return err
+	}
+
+	signer := signed.SignerInfos[0]
+	cert := signingCertificate(certs, signer)
+	if cert == nil {
+		return errors.New("signing certificate not found")
+	}
+	sig.Subject = cert.Subject.String()
+	sig.CommonName = cert.Subject.CommonName
+	sig.Organization = cert.Subject.Organization
+	sig.Issuer = cert.Issuer.String()
+
+	// the file must match the digest, which must be signed by the certificate
+	actual, err := digest(hash)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(actual, indirect.MessageDigest.Digest) {
+		return errors.New("file digest does not match the signed digest")
+	}
+	if err := verifySigner(signer, cert, content.Bytes); err != nil {
+		return err
+	}
+	sig.Valid = true
+
+	// a timestamped signature stays valid after the certificate expires,
+	// the time is only relied upon once the timestamp is verified against the signature
+	sig.Timestamp = timestamp(signer, certs)
+	if err := verifyChain(cert, certs, x509.ExtKeyUsageCodeSigning, sig.Timestamp); err != nil {
+		return err
+	}
+	sig.Trusted = true
+	return nil
+}
+
+// Returns the certificate of a signer among certs, or nil if not found.
+func signingCertificate(certs []*x509.Certificate, signer signerInfo) *x509.Certificate {
+	index := slices.IndexFunc(certs, func(c *x509.Certificate) bool {
+		return bytes.Equal(c.RawIssuer, signer.IssuerAndSerialNumber.Issuer.FullBytes) &&
+			c.SerialNumber.Cmp(signer.IssuerAndSerialNumber.SerialNumber) == 0
+	})
+	if index == -1 {
+		return nil
+	}
+	return certs[index]
+}
+
+// Verifies that cert chains to a trusted root for usage at the given time, or now if zero,
+// with the other certificates as intermediates.
+func verifyChain(cert *x509.Certificate, certs []*x509.Certificate, usage x509.ExtKeyUsage, at time.Time) error {
+	intermediates := x509.NewCertPool()
+	for _, c := range certs {
+		intermediates.AddCert(c)
+	}
+	_, err := cert.Verify(x509.VerifyOptions{
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+		CurrentTime:   at,
+	})
+	return err
+}
+
+// Verifies the signature of a signer over its authenticated attributes, which include the digest of content.
+func verifySigner(signer signerInfo, cert *x509.Certificate, content []byte) error {
+	hash, _, ok := lookupDigest(signer.DigestAlgorithm.Algorithm)
+	if !ok {
+		return fmt.Errorf("unsupported digest algorithm %s", signer.DigestAlgorithm.Algorithm)
+	}
+
+	attributes := signer.AuthenticatedAttributes
+	if len(attributes.FullBytes) == 0 {
+		return errors.New("no authenticated attributes")
+	}
+
+	var messageDigest []byte
+	for _, attr := range parseAttributes(attributes.Bytes) {
+		if attr.Type.Equal(oidMessageDigest) {
+			asn1.Unmarshal(attr.Values.Bytes, &messageDigest)
+		}
+	}
+
+	h := hash.New()
+	h.Write(content)
+	if !bytes.Equal(h.Sum(nil), messageDigest) {
+		return errors.New("message digest does not match the signed content")
+	}
+
+	// the attributes are signed as a SET rather than the implicitly tagged field
+	signed := slices.Clone(attributes.FullBytes)
+	signed[0] = 0x31
+	h = hash.New()
+	h.Write(signed)
+	sum := h.Sum(nil)
+
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, hash, sum, signer.EncryptedDigest)
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, sum, signer.EncryptedDigest) {
+			return errors.New("ECDSA verification failure")
+		}
+		return nil
+	default:
+		return errors.New("unsupported public key algorithm")
+	}
+}
+
+// Returns the time of the countersignature or the RFC 3161 timestamp in the unauthenticated attributes of a signer,
+// or the zero time if there is none that signs the signature of the signer with a certificate trusted for
+// time stamping at the time it gives. The certificates of the signature may issue a countersignature.
+func timestamp(signer signerInfo, certs []*x509.Certificate) time.Time {
+	for _, attr := range parseAttributes(signer.UnauthenticatedAttributes.Bytes) {
+		var (
+			t   time.Time
+			err error
+		)
+		switch {
+		case attr.Type.Equal(oidCounterSignature):
+			t, err = verifyCounterSignature(attr.Values.Bytes, signer.EncryptedDigest, certs)
+		case attr.Type.Equal(oidTimestampToken):
+			t, err = verifyTimestampToken(attr.Values.Bytes, signer.EncryptedDigest)
+		default:
+			continue
+		}
+		if err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Verifies a PKCS#9 countersignature over signature, and returns its signing time.
+func verifyCounterSignature(der []byte, signature []byte, certs []*x509.Certificate) (time.Time, error) {
+	var counter signerInfo
+	if _, err := asn1.Unmarshal(der, &counter); err != nil {
+		return time.Time{}, err
+	}
+
+	cert := signingCertificate(certs, counter)
+	if cert == nil {
+		return time.Time{}, errors.New("countersigning certificate not found")
+	}
+	if err := verifySigner(counter, cert, signature); err != nil {
+		return time.Time{}, err
+	}
+
+	var t time.Time
+	for _, attr := range parseAttributes(counter.AuthenticatedAttributes.Bytes) {
+		if attr.Type.Equal(oidSigningTime) {
+			asn1.Unmarshal(attr.Values.Bytes, &t)
+		}
+	}
+	if t.IsZero() {
+		return time.Time{}, errors.New("no signing time in the countersignature")
+	}
+	return t, verifyChain(cert, certs, x509.ExtKeyUsageTimeStamping, t)
+}
+
+// Verifies an RFC 3161 timestamp token over signature, and returns the time it gives.
+func verifyTimestampToken(der []byte, signature []byte) (time.Time, error) {
+	var (
+		info   contentInfo
+		signed signedData
+		octets []byte
+		tst    tstInfo
+	)
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return time.Time{}, err
+	}
+	if _, err := asn1.Unmarshal(info.Content.Bytes, &signed); err != nil {
+		return time.Time{}, err
+	}
+	if _, err := asn1.Unmarshal(signed.ContentInfo.Content.Bytes, &octets); err != nil {
+		return time.Time{}, err
+	}
+	if _, err := asn1.Unmarshal(octets, &tst); err != nil {
+		return time.Time{}, err
+	}
+	if len(signed.SignerInfos) == 0 {
+		return time.Time{}, errors.New("timestamp token not signed")
+	}
+
+	// the token must be issued for the signature
+	hash, _, ok := lookupDigest(tst.MessageImprint.Algorithm.Algorithm)
+	if !ok {
+		return time.Time{}, fmt.Errorf("unsupported digest algorithm %s", tst.MessageImprint.Algorithm.Algorithm)
+	}
+	h := hash.New()
+	h.Write(signature)
+	if !bytes.Equal(h.Sum(nil), tst.MessageImprint.Digest) {
+		return time.Time{}, errors.New("timestamp token does not match the signature")
+	}
+
+	certs, err := x509.ParseCertificates(signed.Certificates.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+	cert := signingCertificate(certs, signed.SignerInfos[0])
+	if cert == nil {
+		return time.Time{}, errors.New("timestamping certificate not found")
+	}
+	if err := verifySigner(signed.SignerInfos[0], cert, octets); err != nil {
+		return time.Time{}, err
+	}
+	return tst.GenTime, verifyChain(cert, certs, x509.ExtKeyUsageTimeStamping, tst.GenTime)
+}
+
+func parseAttributes(der []byte) []attribute {
+	var attributes []attribute
+	for len(der) > 0 {
+		var attr attribute
+		rest, err := asn1.Unmarshal(der, &attr)
+		if err != nil {
+			break
+		}
+		attributes = append(attributes, attr)
+		der = rest
+	}
+	return attributes
+}
+
+func lookupDigest(oid asn1.ObjectIdentifier) (crypto.Hash, string, bool) {
+	for _, algorithm := range digestAlgorithms {
+		if algorithm.oid.Equal(oid) {
+			return algorithm.hash, algorithm.name, algorithm.hash.Available()
+		}
+	}
+	return 0, "", false
+}
+
+// Computes the Authenticode digest of an executable, which covers the whole file except the excluded ranges.
+func imageDigest(r io.ReaderAt, size int64, hash crypto.Hash, excluded [][2]int64) ([]byte, error) {
+	slices.SortFunc(excluded, func(a, b [2]int64) int { return cmp.Compare(a[0], b[0]) })
+
+	h := hash.New()
+	offset := int64(0)
+	for _, exclusion := range append(excluded, [2]int64{size, 0}) {
+		if exclusion[0] < offset {
+			return nil, errors.New("installer: overlapping excluded ranges")
+		}
+		if _, err := io.Copy(h, io.NewSectionReader(r, offset, exclusion[0]-offset)); err != nil {
+			return nil, err
+		}
+		offset = exclusion[0] + exclusion[1]
+	}
+	return h.Sum(nil), nil
+}
+
+// CheckPolicy returns an error describing how the signature violates the policy, or nil if it does not.
+func CheckPolicy(policy storage.SignaturePolicy, sig Signature) error {
+	switch {
+	case policy.Action == storage.PolicyNone:
+		return nil
+	case !sig.Signed:
+		return errors.New("installer: not signed")
+	case !sig.Valid:
+		return fmt.Errorf("installer: invalid signature: %s", sig.Error)
+	case !sig.Trusted:
+		return fmt.Errorf("installer: untrusted signature: %s", sig.Error)
+	}
+
+	if len(policy.Signers) == 0 {
+		return nil
+	}
+	for _, signer := range policy.Signers {
+		signer = strings.TrimSpace(signer)
+		if strings.EqualFold(signer, sig.CommonName) || slices.ContainsFunc(sig.Organization, func(o string) bool {
+			return strings.EqualFold(signer, o)
+		}) {
+			return nil
+		}
+	}
+	return fmt.Errorf("installer: unexpected signer %s", sig.Subject)
+}
@@ -0,0 +1,130 @@
+package installer
+
+import (
+	"bytes"
+	"driver-box/pkg/storage"
+	"encoding/binary"
+	"os"
+	"testing"
+)
+
+// Reads the signed fixture, see testdata/README.md.
+func readSigned(t *testing.T) []byte {
+	t.Helper()
+	b, err := os.ReadFile("testdata/signed.exe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// Removes the certificate table from the security data directory of an executable.
+func unsign(b []byte) []byte {
+	optionalAt := int(binary.LittleEndian.Uint32(b[0x3C:])) + 4 + 20
+	dirAt := optionalAt + 96 + 8*4
+	if binary.LittleEndian.Uint16(b[optionalAt:]) == 0x20B {
+		dirAt = optionalAt + 112 + 8*4
+	}
+	tableAt := binary.LittleEndian.Uint32(b[dirAt:])
+	clear(b[dirAt : dirAt+8])
+	return b[:tableAt]
+}
+
+// Replaces the first occurrence of old in b.
+func replace(t *testing.T, b []byte, old string, new string) []byte {
+	t.Helper()
+	i := bytes.Index(b, []byte(old))
+	if i == -1 {
+		t.Fatalf("%q not found", old)
+	}
+	copy(b[i:], new)
+	return b
+}
+
+func TestInspectSignature(t *testing.T) {
+	const digest = "338540aca4a45d4a9951a2b20a87d30d332945988fd5fac8f5af5aac6297e5d7"
+
+	tests := []struct {
+		name          string
+		file          func(t *testing.T) []byte
+		wantSigned    bool
+		wantValid     bool
+		wantUntrusted bool // Whether the signature must not be trusted, whatever the trusted roots
+		wantCommon    string
+	}{
+		{"signed", readSigned, true, true, false, "WireGuard LLC"},
+		{"unsigned", func(t *testing.T) []byte { return unsign(readSigned(t)) }, false, false, true, ""},
+		{"modified file", func(t *testing.T) []byte {
+			// the text section is covered by the digest
+			b := readSigned(t)
+			b[0x400] ^= 0xFF
+			return b
+		}, true, false, true, "WireGuard LLC"},
+		{"backdated timestamp", func(t *testing.T) []byte {
+			// the time of the token is no longer covered by its signature, and the certificate has expired since
+			return replace(t, readSigned(t), "20211123170426Z", "20201123170426Z")
+		}, true, true, true, "WireGuard LLC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.file(t)
+			sig, err := InspectSignature(bytes.NewReader(b), int64(len(b)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sig.Signed != tt.wantSigned || sig.Valid != tt.wantValid || sig.CommonName != tt.wantCommon {
+				t.Errorf("signed, valid, common name = %v, %v, %q, want %v, %v, %q",
+					sig.Signed, sig.Valid, sig.CommonName, tt.wantSigned, tt.wantValid, tt.wantCommon)
+			}
+			if tt.wantUntrusted && (sig.Trusted || !sig.Timestamp.IsZero()) {
+				t.Errorf("trusted, timestamp = %v, %v, want untrusted without timestamp", sig.Trusted, sig.Timestamp)
+			}
+			if sig.Signed && sig.Digest != digest {
+				t.Errorf("digest = %s, want %s", sig.Digest, digest)
+			}
+			if sig.Trusted && sig.Timestamp.IsZero() {
+				// the signing certificate has expired, only a verified timestamp can keep it trusted
+				t.Error("trusted without a timestamp")
+			}
+		})
+	}
+}
+
+func TestCheckPolicy(t *testing.T) {
+	trusted := Signature{
+		Signed:       true,
+		Subject:      "SERIALNUMBER=4227913,CN=WireGuard LLC,O=WireGuard LLC,L=Boulder,ST=Colorado,C=US",
+		CommonName:   "WireGuard LLC",
+		Organization: []string{"WireGuard LLC"},
+		Valid:        true,
+		Trusted:      true,
+	}
+	withOrganization := trusted
+	withOrganization.Organization = []string{"Example Corporation", "WireGuard LLC"}
+
+	tests := []struct {
+		name    string
+		policy  storage.SignaturePolicy
+		sig     Signature
+		wantErr bool
+	}{
+		{"not checked", storage.SignaturePolicy{}, Signature{}, false},
+		{"unsigned", storage.SignaturePolicy{Action: storage.PolicyWarn}, Signature{}, true},
+		{"invalid", storage.SignaturePolicy{Action: storage.PolicyBlock}, Signature{Signed: true}, true},
+		{"untrusted", storage.SignaturePolicy{Action: storage.PolicyBlock}, Signature{Signed: true, Valid: true}, true},
+		{"any signer", storage.SignaturePolicy{Action: storage.PolicyBlock}, trusted, false},
+		{"common name", storage.SignaturePolicy{Action: storage.PolicyBlock, Signers: []string{"Intel Corporation", "wireguard llc"}}, trusted, false},
+		{"organization", storage.SignaturePolicy{Action: storage.PolicyBlock, Signers: []string{" Example Corporation "}}, withOrganization, false},
+		{"part of the name", storage.SignaturePolicy{Action: storage.PolicyBlock, Signers: []string{"WireGuard"}}, trusted, true},
+		{"other attribute", storage.SignaturePolicy{Action: storage.PolicyBlock, Signers: []string{"Boulder"}}, trusted, true},
+		{"subject", storage.SignaturePolicy{Action: storage.PolicyBlock, Signers: []string{trusted.Subject}}, trusted, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckPolicy(tt.policy, tt.sig); (err != nil) != tt.wantErr {
+				t.Errorf("CheckPolicy() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package installer
+
+import (
+	"cmp"
+	"debug/pe"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+const rtVersion = 16 // Resource type of VS_VERSIONINFO
+
+var errNoVersionInfo = errors.New("installer: no version information")
+
+// VersionInfo holds the strings of the VS_VERSIONINFO resource of an executable.
+type VersionInfo struct {
+	ProductName    string `json:"productName"`
+	CompanyName    string `json:"companyName"`
+	FileVersion    string `json:"fileVersion"`
+	ProductVersion string `json:"productVersion"`
+}
+
+// ReadVersionInfoFile reads the version information of the executable at path, see [ReadVersionInfo].
+func ReadVersionInfoFile(path string) (VersionInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return VersionInfo{}, err
+	}
+	defer file.Close()
+
+	return ReadVersionInfo(file)
+}
+
+// ReadVersionInfo reads the version information of an executable. The versions fall back to
+// the numeric versions of VS_FIXEDFILEINFO if the string table does not contain them.
+func ReadVersionInfo(r io.ReaderAt) (VersionInfo, error) {
+	file, err := pe.NewFile(r)
+	if err != nil {
+		return VersionInfo{}, err
+	}
+	defer file.Close()
+
+	data, err := versionResource(file)
+	if err != nil {
+		return VersionInfo{}, err
+	}
+
+	var info VersionInfo
+	fixed, table := parseVersionInfo(data)
+	info.ProductName = table["ProductName"]
+	info.CompanyName = table["CompanyName"]
+	info.FileVersion = table["FileVersion"]
+	info.ProductVersion = table["ProductVersion"]
+
+	if len(fixed) >= 24 && binary.LittleEndian.Uint32(fixed) == 0xFEEF04BD {
+		if info.FileVersion == "" {
+			info.FileVersion = fixedVersion(fixed[8:16])
+		}
+		if info.ProductVersion == "" {
+			info.ProductVersion = fixedVersion(fixed[16:24])
+		}
+	}
+	return info, nil
+}
+
+// Returns the data of the first version resource, descending the resource directory by
+// type, then by the first name and the first language.
+func versionResource(file *pe.File) ([]byte, error) {
+	var dir pe.DataDirectory
+	switch header := file.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		if len(header.DataDirectory) > pe.IMAGE_DIRECTORY_ENTRY_RESOURCE {
+			dir = header.DataDirectory[pe.IMAGE_DIRECTORY_ENTRY_RESOURCE]
+		}
+	case *pe.OptionalHeader64:
+		if len(header.DataDirectory) > pe.IMAGE_DIRECTORY_ENTRY_RESOURCE {
+			dir = header.DataDirectory[pe.IMAGE_DIRECTORY_ENTRY_RESOURCE]
+		}
+	}
+	if dir.VirtualAddress == 0 {
+		return nil, errNoVersionInfo
+	}
+
+	var section *pe.Section
+	for _, s := range file.Sections {
+		if dir.VirtualAddress >= s.VirtualAddress && dir.VirtualAddress < s.VirtualAddress+max(s.VirtualSize, s.Size) {
+			section = s
+			break
+		}
+	}
+	if section == nil {
+		return nil, errNoVersionInfo
+	}
+
+	rsrc, err := section.Data()
+	if err != nil {
+		return nil, err
+	}
+	base := dir.VirtualAddress - section.VirtualAddress
+
+	// each level of the directory is an IMAGE_RESOURCE_DIRECTORY followed by its entries
+	entry := func(offset uint32, id int) (uint32, bool) {
+		offset += base
+		if int(offset)+16 > len(rsrc) {
+			return 0, false
+		}
+		named := uint32(binary.LittleEndian.Uint16(rsrc[offset+12:]))
+		count := named + uint32(binary.LittleEndian.Uint16(rsrc[offset+14:]))
+
+		for i := uint32(0); i < count; i++ {
+			at := offset + 16 + i*8
+			if int(at)+8 > len(rsrc) {
+				return 0, false
+			}
+			name := binary.LittleEndian.Uint32(rsrc[at:])
+			if id < 0 || (name&0x80000000 == 0 && int(name) == id) {
+				return binary.LittleEndian.Uint32(rsrc[at+4:]), true
+			}
+		}
+		return 0, false
+	}
+
+	offset := uint32(0)
+	for _, id := range []int{rtVersion, -1} {
+		next, ok := entry(offset, id)
+		if !ok || next&0x80000000 == 0 {
+			return nil, errNoVersionInfo
+		}
+		offset = next &^ 0x80000000
+	}
+
+	// entries of the language level point to an IMAGE_RESOURCE_DATA_ENTRY
+	next, ok := entry(offset, -1)
+	if !ok || next&0x80000000 != 0 || int(base+next)+8 > len(rsrc) {
+		return nil, errNoVersionInfo
+	}
+	rva := binary.LittleEndian.Uint32(rsrc[base+next:])
+	size := binary.LittleEndian.Uint32(rsrc[base+next+4:])
+
+	start := int64(rva) - int64(section.VirtualAddress)
+	if start < 0 || start+int64(size) > int64(len(rsrc)) {
+		return nil, errNoVersionInfo
+	}
+	return rsrc[start : start+int64(size)], nil
+}
+
+// Parses a VS_VERSIONINFO structure, and returns its VS_FIXEDFILEINFO and the strings of its StringFileInfo.
+// Only the first string table is read, which is usually the only one.
+func parseVersionInfo(data []byte) (fixed []byte, table map[string]string) {
+	table = map[string]string{}
+
+	root, ok := readBlock(data)
+	if !ok {
+		return nil, table
+	}
+	fixed = root.value
+
+	for _, child := range root.children() {
+		if child.key != "StringFileInfo" {
+			continue
+		}
+		for _, stringTable := range child.children() {
+			for _, str := range stringTable.children() {
+				table[str.key] = decodeUTF16(str.value)
+			}
+			return fixed, table
+		}
+	}
+	return fixed, table
+}
+
+// block is a node of the VS_VERSIONINFO tree, each of which starts with its length, value length, type and key.
+type block struct {
+	key     string
+	value   []byte
+	content []byte // Children of the block
+}
+
+func readBlock(data []byte) (block, bool) {
+	if len(data) < 6 {
+		return block{}, false
+	}
+
+	length := int(binary.LittleEndian.Uint16(data))
+	valueLength := int(binary.LittleEndian.Uint16(data[2:]))
+	text := binary.LittleEndian.Uint16(data[4:]) == 1
+	if length < 6 || length > len(data) {
+		return block{}, false
+	}
+	data = data[:length]
+
+	// the key is a null terminated UTF-16 string
+	end := 6
+	for end+1 < len(data) && (data[end] != 0 || data[end+1] != 0) {
+		end += 2
+	}
+	b := block{key: decodeUTF16(data[6:end])}
+
+	offset := align4(end + 2)
+	if text {
+		// the length of text values is in characters
+		valueLength *= 2
+	}
+	if offset+valueLength > len(data) {
+		valueLength = max(len(data)-offset, 0)
+	}
+	if offset <= len(data) {
+		b.value = data[offset : offset+valueLength]
+		if next := align4(offset + valueLength); next < len(data) {
+			b.content = data[next:]
+		}
+	}
+	return b, true
+}
+
+func (b block) children() []block {
+	var children []block
+	for data := b.content; len(data) > 0; {
+		child, ok := readBlock(data)
+		if !ok {
+			break
+		}
+		children = append(children, child)
+
+		length := align4(int(binary.LittleEndian.Uint16(data)))
+		if length >= len(data) {
+			break
+		}
+		data = data[length:]
+	}
+	return children
+}
+
+func align4(n int) int {
+	return (n + 3) &^ 3
+}
+
+// Decodes a UTF-16LE string, stopping at the first null character.
+func decodeUTF16(b []byte) string {
+	chars := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		c := binary.LittleEndian.Uint16(b[i:])
+		if c == 0 {
+			break
+		}
+		chars = append(chars, c)
+	}
+	return string(utf16.Decode(chars))
+}
+
+// Formats a version of VS_FIXEDFILEINFO, stored as the most and least significant double words.
+func fixedVersion(b []byte) string {
+	ms, ls := binary.LittleEndian.Uint32(b), binary.LittleEndian.Uint32(b[4:])
+	return fmt.Sprintf("%d.%d.%d.%d", ms>>16, ms&0xFFFF, ls>>16, ls&0xFFFF)
+}
+
+// CompareVersions compares two dotted versions component by component, returning -1, 0 or +1.
+// Components are compared numerically when both are numbers, missing components count as zero.
+func CompareVersions(a, b string) int {
+	as, bs := strings.Split(strings.TrimSpace(a), "."), strings.Split(strings.TrimSpace(b), ".")
+	for i := range max(len(as), len(bs)) {
+		x, y := "0", "0"
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+
+		m, errM := strconv.ParseUint(x, 10, 64)
+		n, errN := strconv.ParseUint(y, 10, 64)
+		if errM == nil && errN == nil {
+			if c := cmp.Compare(m, n); c != 0 {
+				return c
+			}
+		} else if c := strings.Compare(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
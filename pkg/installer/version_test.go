@@ -0,0 +1,179 @@
+package installer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// Builds a node of the VS_VERSIONINFO tree. The length of a text value is given in characters.
+func versionBlock(key string, value []byte, text bool, children ...[]byte) []byte {
+	b := make([]byte, 6)
+	b = append(b, utf16le(key)...)
+	b = append(b, 0, 0)
+	b = append(b, make([]byte, align4(len(b))-len(b))...)
+
+	valueLength := len(value)
+	if text {
+		valueLength /= 2
+	}
+	b = append(b, value...)
+	for _, child := range children {
+		b = append(b, make([]byte, align4(len(b))-len(b))...)
+		b = append(b, child...)
+	}
+
+	binary.LittleEndian.PutUint16(b, uint16(len(b)))
+	binary.LittleEndian.PutUint16(b[2:], uint16(valueLength))
+	if text {
+		binary.LittleEndian.PutUint16(b[4:], 1)
+	}
+	return b
+}
+
+// Builds a String structure of a string table.
+func versionString(key, value string) []byte {
+	return versionBlock(key, append(utf16le(value), 0, 0), true)
+}
+
+// Builds a VS_FIXEDFILEINFO with the file and product versions, both given as four components.
+func fixedFileInfo(file, product [4]uint16) []byte {
+	b := make([]byte, 52)
+	binary.LittleEndian.PutUint32(b, 0xFEEF04BD)
+	binary.LittleEndian.PutUint32(b[4:], 0x00010000)
+	for i, version := range [][4]uint16{file, product} {
+		binary.LittleEndian.PutUint32(b[8+i*8:], uint32(version[0])<<16|uint32(version[1]))
+		binary.LittleEndian.PutUint32(b[12+i*8:], uint32(version[2])<<16|uint32(version[3]))
+	}
+	return b
+}
+
+// Builds a resource section at the virtual address rva holding a single version resource, with
+// one level of the directory per type, name and language.
+func resourceSection(rva uint32, data []byte) []byte {
+	const dataOffset = 3*24 + 16
+
+	var b []byte
+	for _, entry := range [][2]uint32{
+		{rtVersion, 0x80000000 | 24},
+		{1, 0x80000000 | 48},
+		{0x409, 72},
+	} {
+		dir := make([]byte, 24)
+		binary.LittleEndian.PutUint16(dir[14:], 1) // one entry by identifier
+		binary.LittleEndian.PutUint32(dir[16:], entry[0])
+		binary.LittleEndian.PutUint32(dir[20:], entry[1])
+		b = append(b, dir...)
+	}
+
+	// IMAGE_RESOURCE_DATA_ENTRY
+	b = binary.LittleEndian.AppendUint32(b, rva+dataOffset)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(data)))
+	b = append(b, make([]byte, 8)...)
+
+	return append(b, data...)
+}
+
+func TestReadVersionInfo(t *testing.T) {
+	code := peSection{".text", bytes.Repeat([]byte{0xCC}, 64)}
+	fixed := fixedFileInfo([4]uint16{2, 5, 0, 1234}, [4]uint16{2, 5, 0, 0})
+
+	executable := func(version []byte) []byte {
+		return buildPE(t, []peSection{code, {".rsrc", resourceSection(sectionRVA(1), version)}}, nil)
+	}
+
+	tests := []struct {
+		name string
+		file []byte
+		want VersionInfo
+		err  error
+	}{
+		{
+			name: "strings",
+			file: executable(versionBlock("VS_VERSION_INFO", fixed, false,
+				versionBlock("StringFileInfo", nil, true,
+					versionBlock("040904b0", nil, true,
+						versionString("CompanyName", "Realtek Semiconductor Corp."),
+						versionString("FileDescription", "Realtek Audio Setup"),
+						versionString("FileVersion", "6.0.9235.1"),
+						versionString("ProductName", "Realtek High Definition Audio Driver"),
+						versionString("ProductVersion", "6.0.9235"),
+					),
+				),
+				versionBlock("VarFileInfo", nil, true,
+					versionBlock("Translation", []byte{0x09, 0x04, 0xB0, 0x04}, false),
+				),
+			)),
+			want: VersionInfo{
+				ProductName:    "Realtek High Definition Audio Driver",
+				CompanyName:    "Realtek Semiconductor Corp.",
+				FileVersion:    "6.0.9235.1",
+				ProductVersion: "6.0.9235",
+			},
+		},
+		{
+			name: "fixed file info",
+			file: executable(versionBlock("VS_VERSION_INFO", fixed, false,
+				versionBlock("StringFileInfo", nil, true,
+					versionBlock("040904b0", nil, true,
+						versionString("ProductName", "Setup"),
+					),
+				),
+			)),
+			want: VersionInfo{
+				ProductName:    "Setup",
+				FileVersion:    "2.5.0.1234",
+				ProductVersion: "2.5.0.0",
+			},
+		},
+		{
+			name: "no string table",
+			file: executable(versionBlock("VS_VERSION_INFO", fixed, false)),
+			want: VersionInfo{FileVersion: "2.5.0.1234", ProductVersion: "2.5.0.0"},
+		},
+		{
+			name: "no resources",
+			file: buildPE(t, []peSection{code}, nil),
+			err:  errNoVersionInfo,
+		},
+		{
+			name: "signed executable",
+			file: readSigned(t),
+			err:  errNoVersionInfo,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := ReadVersionInfo(bytes.NewReader(tt.file))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ReadVersionInfo() error = %v, want %v", err, tt.err)
+			}
+			if info != tt.want {
+				t.Errorf("ReadVersionInfo() = %+v, want %+v", info, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"1.2", "1.2.0.0", 0},
+		{"1.10", "1.9", 1},
+		{"6.0.9235.1", "6.0.9235", 1},
+		{"2.0", "10.0", -1},
+		{" 1.0 ", "1.0", 0},
+		{"1.0a", "1.0b", -1},
+	}
+
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -124,6 +124,15 @@ func toZip(tracker *Progress, dest string, directories ...string) (err error) {
 	return nil
 }
 
+// Joins the path of an archive entry to dest, and rejects entries escaping dest to prevent the ZipSlip vulnerability.
+func SafeJoin(dest string, name string) (string, error) {
+	path := filepath.Join(dest, name)
+	if !strings.HasPrefix(path, filepath.Clean(dest)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("porting: illegal file path: %s", path)
+	}
+	return path, nil
+}
+
 // fromZip extracts a ZIP archive to the specified destination directory.
 func fromZip(tracker *Progress, orig string, dest string) (err error) {
 	tracker.Start(0)
@@ -149,11 +158,9 @@ func fromZip(tracker *Progress, orig string, dest string) (err error) {
 		}
 		defer zfreader.Close()
 
-		extractPath := filepath.Join(dest, zf.Name)
-
-		// Prevent ZipSlip vulnerability
-		if !strings.HasPrefix(extractPath, filepath.Clean(dest)+string(os.PathSeparator)) {
-			return fmt.Errorf("porting: illegal file path: %s", extractPath)
+		extractPath, err := SafeJoin(dest, zf.Name)
+		if err != nil {
+			return err
 		}
 
 		tracker.message <- fmt.Sprintf("Unpacking: %s", zf.Name)
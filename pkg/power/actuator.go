@@ -0,0 +1,85 @@
+package power
+
+import (
+	"errors"
+	"os/exec"
+	"sync"
+)
+
+// Actuator performs power actions on the machine immediately.
+type Actuator interface {
+	Shutdown() error
+	Reboot() error
+	// Reboots the machine into the UEFI firmware setup.
+	RebootToFirmware() error
+}
+
+// System performs power actions with the Windows built-in shutdown command.
+type System struct{}
+
+func (System) Shutdown() error {
+	return shutdown("/s", "/t", "0")
+}
+
+func (System) Reboot() error {
+	return shutdown("/r", "/t", "0")
+}
+
+func (System) RebootToFirmware() error {
+	// "/fw" sometimes fails with "The system could not find the environment option that was entered. (203)",
+	// executing it again normally solves the error
+	if err := shutdown("/r", "/fw", "/t", "0"); err == nil {
+		return nil
+	} else if err := shutdown("/r", "/fw", "/t", "0"); err != nil {
+		return errors.Join(err, errors.New("power: the firmware may not support booting into UEFI setup"))
+	}
+	return nil
+}
+
+func shutdown(args ...string) error {
+	cmd := exec.Command("shutdown", args...)
+	hideWindow(cmd)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if len(output) > 0 {
+			return errors.Join(err, errors.New(string(output)))
+		}
+		return err
+	}
+	return nil
+}
+
+// Fake records the requested power actions instead of performing them.
+type Fake struct {
+	Err     error    // Error to be returned by every action
+	Actions []string // Names of the performed actions, in order
+	mu      sync.Mutex
+}
+
+func (f *Fake) Shutdown() error {
+	return f.record("shutdown")
+}
+
+func (f *Fake) Reboot() error {
+	return f.record("reboot")
+}
+
+func (f *Fake) RebootToFirmware() error {
+	return f.record("firmware")
+}
+
+// Returns a copy of the performed actions.
+func (f *Fake) Performed() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.Actions...)
+}
+
+func (f *Fake) record(action string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Actions = append(f.Actions, action)
+	return f.Err
+}
@@ -0,0 +1,211 @@
+package power
+
+import (
+	"context"
+	"driver-box/pkg/status"
+	"driver-box/pkg/storage"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Manager schedules a power action after a countdown, which can be cancelled or postponed before it ends.
+//
+// Events emitted to the frontend:
+//   - "power:countdown": [Countdown], once per [Manager.Tick]
+//   - "power:cancelled": [Countdown]
+//   - "power:executed": the performed [storage.SuccessAction]
+//   - "power:failed": the performed [storage.SuccessAction] and the error message
+type Manager struct {
+	Actuator Actuator
+	Tick     time.Duration // Interval between countdown events, defaults to one second
+
+	ctx     context.Context
+	mu      sync.Mutex
+	current *schedule
+}
+
+// Countdown describes a scheduled power action.
+type Countdown struct {
+	Action    storage.SuccessAction `json:"action"`
+	Deadline  time.Time             `json:"deadline"`
+	Remaining int                   `json:"remaining"` // Remaining seconds before the action is performed
+}
+
+type schedule struct {
+	action     storage.SuccessAction
+	deadline   time.Time
+	cancel     chan struct{}
+	performing bool // Set once the deadline is reached, the action can then no longer be cancelled
+}
+
+var errPerforming = errors.New("power: the scheduled action is being performed")
+
+func (m *Manager) SetContext(ctx context.Context) {
+	m.ctx = ctx
+}
+
+// Schedules the action to be performed after delay seconds, replacing any scheduled action.
+// The action is resolved with the summary of the installation, see [storage.SuccessAction.Resolve].
+func (m *Manager) Schedule(action storage.SuccessAction, delay int, summary status.Summary) (Countdown, error) {
+	switch action = action.Resolve(summary); action {
+	case storage.Nothing:
+		if err := m.Cancel(); errors.Is(err, errPerforming) {
+			return Countdown{}, err
+		}
+		return Countdown{Action: storage.Nothing}, nil
+	case storage.Shutdown, storage.Reboot, storage.Firmware:
+	default:
+		return Countdown{}, errors.New("power: unknown action")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.current != nil {
+		if m.current.performing {
+			return Countdown{}, errPerforming
+		}
+		close(m.current.cancel)
+	}
+
+	m.current = &schedule{
+		action:   action,
+		deadline: time.Now().Add(time.Duration(max(delay, 0)) * time.Second),
+		cancel:   make(chan struct{}),
+	}
+	go m.countdown(m.current)
+
+	return m.current.countdown(), nil
+}
+
+// Cancels the scheduled action.
+func (m *Manager) Cancel() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.current == nil {
+		return errors.New("power: no scheduled action")
+	}
+	if m.current.performing {
+		return errPerforming
+	}
+
+	close(m.current.cancel)
+	m.emit("power:cancelled", m.current.countdown())
+	m.current = nil
+	return nil
+}
+
+// Delays the scheduled action by the given seconds.
+func (m *Manager) Postpone(seconds int) (Countdown, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.current == nil {
+		return Countdown{}, errors.New("power: no scheduled action")
+	}
+	if m.current.performing {
+		return Countdown{}, errPerforming
+	}
+
+	m.current.deadline = m.current.deadline.Add(time.Duration(seconds) * time.Second)
+	return m.current.countdown(), nil
+}
+
+// Returns the scheduled action, or a countdown of [storage.Nothing] if there is none.
+func (m *Manager) Scheduled() Countdown {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.current == nil {
+		return Countdown{Action: storage.Nothing}
+	}
+	return m.current.countdown()
+}
+
+func (m *Manager) countdown(s *schedule) {
+	tick := m.Tick
+	if tick <= 0 {
+		tick = time.Second
+	}
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		m.mu.Lock()
+		select {
+		case <-s.cancel:
+			m.mu.Unlock()
+			return
+		default:
+		}
+
+		// the schedule stays current while performed, so that it cannot be replaced by another one
+		countdown := s.countdown()
+		s.performing = countdown.Remaining <= 0
+		m.mu.Unlock()
+
+		if countdown.Remaining <= 0 {
+			break
+		}
+
+		m.emit("power:countdown", countdown)
+
+		select {
+		case <-s.cancel:
+			return
+		case <-ticker.C:
+		}
+	}
+
+	err := m.perform(s.action)
+
+	m.mu.Lock()
+	if m.current == s {
+		m.current = nil
+	}
+	m.mu.Unlock()
+
+	if err != nil {
+		m.emit("power:failed", s.action, err.Error())
+	} else {
+		m.emit("power:executed", s.action)
+	}
+}
+
+func (m *Manager) perform(action storage.SuccessAction) error {
+	actuator := m.Actuator
+	if actuator == nil {
+		actuator = System{}
+	}
+
+	switch action {
+	case storage.Shutdown:
+		return actuator.Shutdown()
+	case storage.Reboot:
+		return actuator.Reboot()
+	case storage.Firmware:
+		return actuator.RebootToFirmware()
+	default:
+		return nil
+	}
+}
+
+func (m *Manager) emit(event string, data ...interface{}) {
+	if m.ctx != nil {
+		runtime.EventsEmit(m.ctx, event, data...)
+	}
+}
+
+func (s schedule) countdown() Countdown {
+	return Countdown{
+		Action:    s.action,
+		Deadline:  s.deadline,
+		Remaining: int(math.Ceil(time.Until(s.deadline).Seconds())),
+	}
+}
@@ -0,0 +1,144 @@
+package power
+
+import (
+	"driver-box/pkg/status"
+	"driver-box/pkg/storage"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// Waits until the actuator has performed n actions, or fails the test after a few seconds.
+func waitPerformed(t *testing.T, fake *Fake, n int) []string {
+	t.Helper()
+
+	for deadline := time.Now().Add(3 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if performed := fake.Performed(); len(performed) >= n {
+			return performed
+		}
+	}
+	t.Fatalf("performed %v, want %d actions", fake.Performed(), n)
+	return nil
+}
+
+func TestSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  storage.SuccessAction
+		summary status.Summary
+		want    []string
+	}{
+		{"shutdown", storage.Shutdown, status.Summary{}, []string{"shutdown"}},
+		{"reboot", storage.Reboot, status.Summary{}, []string{"reboot"}},
+		{"firmware", storage.Firmware, status.Summary{}, []string{"firmware"}},
+		{"reboot required", storage.RebootIfRequired, status.Summary{RebootRequired: true}, []string{"reboot"}},
+		{"reboot not required", storage.RebootIfRequired, status.Summary{}, nil},
+		{"nothing", storage.Nothing, status.Summary{RebootRequired: true}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &Fake{}
+			m := &Manager{Actuator: fake, Tick: 10 * time.Millisecond}
+
+			if _, err := m.Schedule(tt.action, 0, tt.summary); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.want == nil {
+				time.Sleep(100 * time.Millisecond)
+				if performed := fake.Performed(); len(performed) != 0 {
+					t.Fatalf("performed %v, want nothing", performed)
+				}
+				return
+			}
+
+			if performed := waitPerformed(t, fake, len(tt.want)); !slices.Equal(performed, tt.want) {
+				t.Fatalf("performed %v, want %v", performed, tt.want)
+			}
+
+			// cleared once the actuator returns
+			for deadline := time.Now().Add(time.Second); m.Scheduled().Action != storage.Nothing; time.Sleep(10 * time.Millisecond) {
+				if time.Now().After(deadline) {
+					t.Fatalf("scheduled %s after performed", m.Scheduled().Action)
+				}
+			}
+		})
+	}
+}
+
+func TestCancel(t *testing.T) {
+	fake := &Fake{}
+	m := &Manager{Actuator: fake, Tick: 10 * time.Millisecond}
+
+	if _, err := m.Schedule(storage.Shutdown, 1, status.Summary{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Cancel(); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+	if performed := fake.Performed(); len(performed) != 0 {
+		t.Fatalf("performed %v after cancelled", performed)
+	}
+	if err := m.Cancel(); err == nil {
+		t.Fatal("cancelled without a scheduled action")
+	}
+}
+
+func TestReplace(t *testing.T) {
+	fake := &Fake{}
+	m := &Manager{Actuator: fake, Tick: 10 * time.Millisecond}
+
+	if _, err := m.Schedule(storage.Shutdown, 1, status.Summary{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Schedule(storage.Reboot, 1, status.Summary{}); err != nil {
+		t.Fatal(err)
+	}
+
+	waitPerformed(t, fake, 1)
+	time.Sleep(100 * time.Millisecond)
+	if performed := fake.Performed(); !slices.Equal(performed, []string{"reboot"}) {
+		t.Fatalf("performed %v, want only the replacing action", performed)
+	}
+}
+
+// Blocks the actions until released, to observe the manager while an action is performed.
+type blockingActuator struct {
+	*Fake
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b blockingActuator) Reboot() error {
+	close(b.started)
+	<-b.release
+	return b.Fake.Reboot()
+}
+
+func TestScheduleWhilePerforming(t *testing.T) {
+	actuator := blockingActuator{Fake: &Fake{}, started: make(chan struct{}), release: make(chan struct{})}
+	m := &Manager{Actuator: actuator, Tick: 10 * time.Millisecond}
+
+	if _, err := m.Schedule(storage.Reboot, 0, status.Summary{}); err != nil {
+		t.Fatal(err)
+	}
+	<-actuator.started
+
+	if _, err := m.Schedule(storage.Shutdown, 0, status.Summary{}); !errors.Is(err, errPerforming) {
+		t.Fatalf("scheduled while performing: %v", err)
+	}
+	if err := m.Cancel(); !errors.Is(err, errPerforming) {
+		t.Fatalf("cancelled while performing: %v", err)
+	}
+	close(actuator.release)
+
+	waitPerformed(t, actuator.Fake, 1)
+	time.Sleep(100 * time.Millisecond)
+	if performed := actuator.Performed(); !slices.Equal(performed, []string{"reboot"}) {
+		t.Fatalf("performed %v, want only the first action", performed)
+	}
+}
@@ -12,4 +12,31 @@ const (
 	Skiped    Status = "skiped"
 	Speeded   Status = "speeded"
 	Errored   Status = "errored"
+	// Completed successfully, but a reboot is required to finish the installation
+	RebootRequired Status = "reboot_required"
 )
+
+// Summary aggregates the final statuses of a batch of tasks.
+type Summary struct {
+	Counts         map[Status]int `json:"counts"`
+	Total          int            `json:"total"`
+	Succeeded      bool           `json:"succeeded"`      // No task failed: all are completed, with or without a reboot required, or skipped
+	RebootRequired bool           `json:"rebootRequired"` // At least one task requires a reboot
+}
+
+// Summarise counts the given statuses into a [Summary].
+func Summarise(statuses ...Status) Summary {
+	summary := Summary{Counts: make(map[Status]int), Total: len(statuses), Succeeded: true}
+	for _, s := range statuses {
+		summary.Counts[s]++
+		switch s {
+		case RebootRequired:
+			summary.RebootRequired = true
+		case Completed, Skiped:
+			// drivers are skipped on purpose, e.g. when incompatible or already installed
+		default:
+			summary.Succeeded = false
+		}
+	}
+	return summary
+}
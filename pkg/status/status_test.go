@@ -0,0 +1,33 @@
+package status
+
+import "testing"
+
+func TestSummarise(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []Status
+		succeeded bool
+		reboot    bool
+	}{
+		{"empty", nil, true, false},
+		{"completed", []Status{Completed, Completed}, true, false},
+		{"reboot required", []Status{Completed, RebootRequired}, true, true},
+		{"skipped", []Status{Completed, Skiped}, true, false},
+		{"failed", []Status{Completed, Skiped, Failed}, false, false},
+		{"speeded", []Status{Speeded}, false, false},
+		{"aborted", []Status{RebootRequired, Aborted}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := Summarise(tt.statuses...)
+			if summary.Succeeded != tt.succeeded || summary.RebootRequired != tt.reboot {
+				t.Errorf("Summarise(%v) = succeeded %v, reboot %v, want %v, %v",
+					tt.statuses, summary.Succeeded, summary.RebootRequired, tt.succeeded, tt.reboot)
+			}
+			if summary.Total != len(tt.statuses) {
+				t.Errorf("Total = %d, want %d", summary.Total, len(tt.statuses))
+			}
+		})
+	}
+}
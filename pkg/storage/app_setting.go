@@ -1,40 +1,92 @@
 package storage
 
 import (
+	"driver-box/pkg/status"
 	"encoding/json"
 	"os"
+	"sync"
 )
 
 type AppSettingManager struct {
 	Path    string
 	setting AppSetting
+	session *AppSetting // Setting overriding the one written until the application exits
 	fstat   os.FileInfo
+	mu      sync.Mutex // Guards the settings, as the manager is used by the frontend and the executor concurrently
 }
 
 func (s *AppSettingManager) Read() (AppSetting, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.read(); err != nil {
+		return AppSetting{}, err
+	}
+
+	if s.session != nil {
+		return *s.session, nil
+	}
+	return s.setting, nil
+}
+
+// Returns the setting written, ignoring the override of [AppSettingManager.Override].
+func (s *AppSettingManager) written() (AppSetting, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.read(); err != nil {
+		return AppSetting{}, err
+	}
+	return s.setting, nil
+}
+
+// Reports whether the setting is overridden for the session.
+func (s *AppSettingManager) overridden() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.session != nil
+}
+
+// Reloads the setting if the file has changed, the caller must hold the lock.
+func (s *AppSettingManager) read() error {
 	if s.fstat == nil || s.modified() {
 		var setting AppSetting
 
 		if _, err := os.Stat(s.Path); err != nil {
-			s.Update(AppSetting{SuccessAction: Nothing, SuccessActionDelay: 5, Language: "en"})
+			s.write(AppSetting{
+				SuccessAction:      Nothing,
+				SuccessActionDelay: 5,
+				Language:           "en",
+				MaxConcurrency:     defaultMaxConcurrency,
+				ResourceLimits:     defaultResourceLimits(),
+			})
 		}
 
 		bytes, err := os.ReadFile(s.Path)
 		if err != nil {
-			return AppSetting{}, err
+			return err
 		}
 
 		if err := json.Unmarshal(bytes, &setting); err != nil {
-			return AppSetting{}, err
+			return err
 		}
 
 		s.setting = setting
 	}
-
-	return s.setting, nil
+	return nil
 }
 
+// Update writes the setting, which ends the override of [AppSettingManager.Override].
 func (s *AppSettingManager) Update(setting AppSetting) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.session = nil
+	return s.write(setting)
+}
+
+func (s *AppSettingManager) write(setting AppSetting) error {
 	s.setting = setting
 
 	bytes, err := json.Marshal(s.setting)
@@ -51,7 +103,24 @@ func (s *AppSettingManager) Update(setting AppSetting) error {
 
 }
 
-func (s AppSettingManager) modified() bool {
+// Override replaces the setting read for the rest of the session without writing it,
+// e.g. the options changed for a single installation.
+func (s *AppSettingManager) Override(setting AppSetting) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.session = &setting
+}
+
+// Restore discards the override of [AppSettingManager.Override].
+func (s *AppSettingManager) Restore() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.session = nil
+}
+
+func (s *AppSettingManager) modified() bool {
 	if s.fstat == nil {
 		return false
 	}
@@ -64,17 +133,59 @@ func (s AppSettingManager) modified() bool {
 }
 
 type AppSetting struct {
-	CreatePartition    bool          `json:"create_partition"`
-	SetPassword        bool          `json:"set_password"`
-	Password           string        `json:"password"`
-	ParallelInstall    bool          `json:"parallel_install"`
-	SuccessAction      SuccessAction `json:"success_action"`
-	SuccessActionDelay int           `json:"success_action_delay"`
-	FilterMiniportNic  bool          `json:"filter_miniport_nic"`
-	FilterMicrosoftNic bool          `json:"filter_microsoft_nic"`
-	Language           string        `json:"language"`
-	DriverDownloadUrl  string        `json:"driver_download_url"`
-	AutoCheckUpdate    bool          `json:"auto_check_update"`
+	CreatePartition    bool           `json:"create_partition"`
+	SetPassword        bool           `json:"set_password"`
+	Password           string         `json:"password"`
+	MaxConcurrency     int            `json:"max_concurrency"` // Maximum number of running installers, no limit if less than 1
+	ResourceLimits     map[string]int `json:"resource_limits"` // Maximum number of running installers per [DriverGroup.ResourceClass]
+	SuccessAction      SuccessAction  `json:"success_action"`
+	SuccessActionDelay int            `json:"success_action_delay"`
+	FilterMiniportNic  bool           `json:"filter_miniport_nic"`
+	FilterMicrosoftNic bool           `json:"filter_microsoft_nic"`
+	Language           string         `json:"language"`
+	DriverDownloadUrl  string         `json:"driver_download_url"`
+	AutoCheckUpdate    bool           `json:"auto_check_update"`
+}
+
+// Defaults of the concurrency limits, also applied to setting files written before the limits existed.
+const defaultMaxConcurrency = 1
+
+func defaultResourceLimits() map[string]int {
+	return map[string]int{"msiexec": 1}
+}
+
+// Applies the default concurrency limits if missing, or migrates the legacy "parallel_install"
+// option into [AppSetting.MaxConcurrency].
+func (s *AppSetting) UnmarshalJSON(data []byte) error {
+	type alias AppSetting
+
+	// the fields of the alias are shadowed to tell the missing keys from the zero values
+	legacy := struct {
+		*alias
+		MaxConcurrency  *int            `json:"max_concurrency"`
+		ResourceLimits  *map[string]int `json:"resource_limits"`
+		ParallelInstall *bool           `json:"parallel_install"`
+	}{alias: (*alias)(s)}
+
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return err
+	}
+
+	switch {
+	case legacy.MaxConcurrency != nil:
+		s.MaxConcurrency = *legacy.MaxConcurrency
+	case legacy.ParallelInstall != nil && *legacy.ParallelInstall:
+		s.MaxConcurrency = 0
+	default:
+		s.MaxConcurrency = defaultMaxConcurrency
+	}
+
+	if legacy.ResourceLimits != nil {
+		s.ResourceLimits = *legacy.ResourceLimits
+	} else {
+		s.ResourceLimits = defaultResourceLimits()
+	}
+	return nil
 }
 
 type SuccessAction string
@@ -84,4 +195,17 @@ const (
 	Shutdown SuccessAction = "shutdown"
 	Reboot   SuccessAction = "reboot"
 	Firmware SuccessAction = "firmware"
+	// Reboot only if any of the installation requires a reboot
+	RebootIfRequired SuccessAction = "reboot_if_required"
 )
+
+// Resolve returns the action to be actually performed after a batch of installation.
+func (a SuccessAction) Resolve(summary status.Summary) SuccessAction {
+	if a != RebootIfRequired {
+		return a
+	}
+	if summary.RebootRequired {
+		return Reboot
+	}
+	return Nothing
+}
@@ -5,15 +5,37 @@ import (
 	"errors"
 	"os"
 	"slices"
+	"sync"
+	"time"
 )
 
 type DriverGroupManager struct {
-	Path   string
-	groups []DriverGroup
-	fstat  os.FileInfo
+	Path string
+	// Reads the metadata of driver files, metadata are not refreshed if nil
+	Metadata MetadataReader
+	groups   []DriverGroup
+	fstat    os.FileInfo
+	mu       sync.Mutex // Guards the groups, as the manager is used by the frontend and the executor concurrently
+}
+
+// MetadataReader reads the metadata of the file at the path of a driver.
+type MetadataReader interface {
+	// Returns the metadata of the driver, or its current metadata if the file has not changed since they were read.
+	ReadMetadata(driver Driver) (DriverMetadata, error)
 }
 
 func (m *DriverGroupManager) Read() ([]DriverGroup, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.read(); err != nil {
+		return nil, err
+	}
+	return slices.Clone(m.groups), nil
+}
+
+// Reloads the groups if the file has changed, the caller must hold the lock.
+func (m *DriverGroupManager) read() error {
 	if m.fstat == nil || m.modified() {
 		var groups []DriverGroup
 
@@ -23,16 +45,53 @@ func (m *DriverGroupManager) Read() ([]DriverGroup, error) {
 
 		bytes, err := os.ReadFile(m.Path)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		if err := json.Unmarshal(bytes, &groups); err != nil {
-			return nil, err
+			return err
 		}
 
 		m.groups = groups
 	}
-	return m.groups, nil
+	return nil
+}
+
+// Refreshes the metadata of the drivers whose files have changed since they were read, and returns the groups.
+// Metadata are otherwise only read when a group is added or updated.
+func (m *DriverGroupManager) RefreshMetadata() ([]DriverGroup, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.read(); err != nil {
+		return nil, err
+	}
+
+	if m.refreshMetadata(m.groups) {
+		if err := m.write(); err != nil {
+			return nil, err
+		}
+	}
+	return slices.Clone(m.groups), nil
+}
+
+// Refreshes the metadata of the drivers in groups whose files have changed, and reports whether any was refreshed.
+// Drivers whose files cannot be read keep their metadata.
+func (m *DriverGroupManager) refreshMetadata(groups []DriverGroup) bool {
+	if m.Metadata == nil {
+		return false
+	}
+
+	refreshed := false
+	for i := range groups {
+		for j, driver := range groups[i].Drivers {
+			if metadata, err := m.Metadata.ReadMetadata(driver); err == nil && metadata != driver.Metadata {
+				groups[i].Drivers[j].Metadata = metadata
+				refreshed = true
+			}
+		}
+	}
+	return refreshed
 }
 
 func (m *DriverGroupManager) write() error {
@@ -49,7 +108,7 @@ func (m *DriverGroupManager) write() error {
 	}
 }
 
-func (m DriverGroupManager) modified() bool {
+func (m *DriverGroupManager) modified() bool {
 	if m.fstat == nil {
 		return false
 	}
@@ -61,7 +120,14 @@ func (m DriverGroupManager) modified() bool {
 	}
 }
 
-func (m DriverGroupManager) IndexOf(groupId string) (int, error) {
+func (m *DriverGroupManager) IndexOf(groupId string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.indexOf(groupId)
+}
+
+func (m *DriverGroupManager) indexOf(groupId string) (int, error) {
 	index := slices.IndexFunc(m.groups, func(g DriverGroup) bool {
 		return g.Id == groupId
 	})
@@ -72,7 +138,14 @@ func (m DriverGroupManager) IndexOf(groupId string) (int, error) {
 	return index, nil
 }
 
-func (m DriverGroupManager) GroupOf(driverId string) (string, error) {
+func (m *DriverGroupManager) GroupOf(driverId string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.groupOf(driverId)
+}
+
+func (m *DriverGroupManager) groupOf(driverId string) (string, error) {
 	for _, group := range m.groups {
 		for _, driver := range group.Drivers {
 			if driver.Id == driverId {
@@ -84,18 +157,21 @@ func (m DriverGroupManager) GroupOf(driverId string) (string, error) {
 }
 
 func (m *DriverGroupManager) Get(id string) (DriverGroup, error) {
-	if index, err := m.IndexOf(id); err != nil {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if index, err := m.indexOf(id); err != nil {
 		return DriverGroup{}, err
 	} else {
 		return m.groups[index], nil
 	}
 }
 
-func (m DriverGroupManager) generateGid() string {
+func (m *DriverGroupManager) generateGid() string {
 	for {
 		if id, err := randomString(4); err != nil {
 			continue
-		} else if _, err := m.GroupOf(id); err == nil {
+		} else if _, err := m.groupOf(id); err == nil {
 			continue
 		} else {
 			return id
@@ -104,10 +180,13 @@ func (m DriverGroupManager) generateGid() string {
 }
 
 func (m *DriverGroupManager) Add(group DriverGroup) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	for group.Id = ""; group.Id == ""; {
 		if id, err := randomString(4); err != nil {
 			continue
-		} else if idx, _ := m.IndexOf(id); idx != -1 {
+		} else if idx, _ := m.indexOf(id); idx != -1 {
 			continue
 		} else {
 			group.Id = id
@@ -120,12 +199,16 @@ func (m *DriverGroupManager) Add(group DriverGroup) (string, error) {
 		}
 	}
 
+	m.refreshMetadata([]DriverGroup{group})
 	m.groups = append(m.groups, group)
 	return group.Id, m.write()
 }
 
 func (m *DriverGroupManager) Update(group DriverGroup) error {
-	if index, err := m.IndexOf(group.Id); err != nil {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if index, err := m.indexOf(group.Id); err != nil {
 		return err
 	} else {
 		for idx := range group.Drivers {
@@ -134,13 +217,17 @@ func (m *DriverGroupManager) Update(group DriverGroup) error {
 			}
 		}
 
+		m.refreshMetadata([]DriverGroup{group})
 		m.groups[index] = group
 		return m.write()
 	}
 }
 
 func (m *DriverGroupManager) Remove(id string) error {
-	if index, err := m.IndexOf(id); err != nil {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if index, err := m.indexOf(id); err != nil {
 		return err
 	} else {
 		// for _, group := range m.groups {
@@ -154,15 +241,18 @@ func (m *DriverGroupManager) Remove(id string) error {
 }
 
 func (m *DriverGroupManager) MoveBehind(id string, index int) ([]DriverGroup, error) {
-	if srcIndex, err := m.IndexOf(id); err != nil {
-		return m.groups, err
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if srcIndex, err := m.indexOf(id); err != nil {
+		return slices.Clone(m.groups), err
 	} else {
 		if index < -1 || index >= len(m.groups)-1 {
-			return m.groups, errors.New("storage: target index out of bound")
+			return slices.Clone(m.groups), errors.New("storage: target index out of bound")
 		}
 
 		if len(m.groups) == 1 || srcIndex-index == 1 {
-			return m.groups, nil
+			return slices.Clone(m.groups), nil
 		}
 
 		if srcIndex <= index {
@@ -174,7 +264,7 @@ func (m *DriverGroupManager) MoveBehind(id string, index int) ([]DriverGroup, er
 				m.groups[i-1], m.groups[i] = m.groups[i], m.groups[i-1]
 			}
 		}
-		return m.groups, m.write()
+		return slices.Clone(m.groups), m.write()
 	}
 }
 
@@ -183,8 +273,29 @@ type DriverGroup struct {
 	Name    string     `json:"name"`
 	Type    DriverType `json:"type"`
 	Drivers []Driver   `json:"drivers"`
+	// Optional class shared by groups competing for the same resource, MSI packages always run in the "msiexec" class
+	ResourceClass   string          `json:"resourceClass"`
+	SignaturePolicy SignaturePolicy `json:"signaturePolicy"`
 }
 
+// SignaturePolicy decides what happens when the executable of a driver
+// is not signed, or not signed by one of the expected vendors.
+type SignaturePolicy struct {
+	Action PolicyAction `json:"action"`
+	// Expected vendors, each matched case-insensitively against the whole common name
+	// or one of the organizations in the subject of the signing certificate.
+	// Any trusted signer is accepted if empty.
+	Signers []string `json:"signers"`
+}
+
+type PolicyAction string
+
+const (
+	PolicyNone  PolicyAction = ""      // Signatures are not checked
+	PolicyWarn  PolicyAction = "warn"  // Violations are recorded as events of the command
+	PolicyBlock PolicyAction = "block" // Violations prevent the driver from running
+)
+
 type DriverType string
 
 const (
@@ -193,13 +304,137 @@ const (
 	Miscellaneous DriverType = "miscellaneous"
 )
 
+type DriverKind string
+
+const (
+	KindExecutable DriverKind = "executable" // Runs the program at [Driver.Path], the default if empty
+	KindScript     DriverKind = "script"     // Runs [Driver.Script] with [Driver.Interpreter]
+	KindArchive    DriverKind = "archive"    // Runs [Driver.Entry] inside the ZIP archive at [Driver.Path]
+	KindMsi        DriverKind = "msi"        // Installs the Windows Installer package at [Driver.Path] with msiexec
+	KindInf        DriverKind = "inf"        // Installs the driver package of the INF file at [Driver.Path] with pnputil
+)
+
+// Returns the exit codes of the installer run by the kind.
+func (k DriverKind) ExitCodes() map[int32]ExitCodeMeaning {
+	switch k {
+	case KindMsi:
+		return MsiExitCodes
+	case KindInf:
+		return PnpUtilExitCodes
+	default:
+		return DefaultExitCodes
+	}
+}
+
+type Interpreter string
+
+const (
+	PowerShell Interpreter = "powershell"
+	Cmd        Interpreter = "cmd"
+	Bash       Interpreter = "bash"
+)
+
 type Driver struct {
-	Id            string     `json:"id"`
-	Name          string     `json:"name"`
-	Type          DriverType `json:"type"`
-	Path          string     `json:"path"`
-	Flags         []string   `json:"flags"`
-	MinExeTime    float32    `json:"minExeTime"`
-	AllowRtCodes  []int32    `json:"allowRtCodes"`
-	Incompatibles []string   `json:"incompatibles"`
+	Id            string      `json:"id"`
+	Name          string      `json:"name"`
+	Type          DriverType  `json:"type"`
+	Kind          DriverKind  `json:"kind"`
+	Path          string      `json:"path"`
+	Flags         []string    `json:"flags"`
+	Script        string      `json:"script"`      // Body of the script, for drivers of [KindScript]
+	Interpreter   Interpreter `json:"interpreter"` // Interpreter of the script, for drivers of [KindScript]
+	Entry         string      `json:"entry"`       // Path of the program inside the archive, for drivers of [KindArchive]
+	MinExeTime    float32     `json:"minExeTime"`
+	AllowRtCodes  []int32     `json:"allowRtCodes"`
+	Incompatibles []string    `json:"incompatibles"`
+	Env           []string    `json:"env"`
+	WorkDir       string      `json:"workDir"`
+	IdleTimeout   float32     `json:"idleTimeout"` // Seconds without CPU or I/O activity before the driver is aborted, disabled if 0
+	Encoding      string      `json:"encoding"`    // Encoding of the output, e.g. "utf-8", "utf-16le" or "cp950", detected if empty or "auto"
+	// Meanings of exit codes, which take precedence over the exit codes of the kind
+	ExitCodes map[int32]ExitCodeMeaning `json:"exitCodes"`
+	// Rules matched in order against the output after the driver exits, the first match decides the status
+	OutputRules []OutputRule   `json:"outputRules"`
+	Metadata    DriverMetadata `json:"metadata"`
+}
+
+// DriverMetadata is read from the version information of the file at the path of a driver,
+// or from the Property table of a Windows Installer package.
+type DriverMetadata struct {
+	ProductName    string    `json:"productName"`
+	CompanyName    string    `json:"companyName"`
+	FileVersion    string    `json:"fileVersion"`
+	ProductVersion string    `json:"productVersion"`
+	ModTime        time.Time `json:"modTime"` // Modification time of the file when the metadata were read
+	Size           int64     `json:"size"`    // Size of the file when the metadata were read
+}
+
+// Classifies an exit code by looking up [Driver.ExitCodes], [Driver.AllowRtCodes] and
+// the exit codes of its kind in order. Unknown exit codes are considered fatal.
+func (d Driver) ExitCodeMeaning(code int32) ExitCodeMeaning {
+	if meaning, ok := d.ExitCodes[code]; ok {
+		return meaning
+	}
+	if slices.Contains(d.AllowRtCodes, code) {
+		return ExitSuccess
+	}
+	if meaning, ok := d.Kind.ExitCodes()[code]; ok {
+		return meaning
+	}
+	return ExitFatal
 }
+
+type ExitCodeMeaning string
+
+const (
+	ExitSuccess   ExitCodeMeaning = "success"
+	ExitReboot    ExitCodeMeaning = "reboot"
+	ExitRetryable ExitCodeMeaning = "retryable"
+	ExitFatal     ExitCodeMeaning = "fatal"
+	ExitSkipped   ExitCodeMeaning = "skipped"
+)
+
+// Exit codes commonly returned by Windows installers.
+var DefaultExitCodes = map[int32]ExitCodeMeaning{
+	0:    ExitSuccess,
+	1618: ExitRetryable, // ERROR_INSTALL_ALREADY_RUNNING
+	1641: ExitReboot,    // ERROR_SUCCESS_REBOOT_INITIATED
+	3010: ExitReboot,    // ERROR_SUCCESS_REBOOT_REQUIRED
+}
+
+// Exit codes of msiexec.
+var MsiExitCodes = map[int32]ExitCodeMeaning{
+	0:    ExitSuccess,
+	1603: ExitFatal,     // ERROR_INSTALL_FAILURE
+	1618: ExitRetryable, // ERROR_INSTALL_ALREADY_RUNNING
+	1638: ExitSkipped,   // ERROR_PRODUCT_VERSION, another version of the product is already installed
+	1641: ExitReboot,    // ERROR_SUCCESS_REBOOT_INITIATED
+	3010: ExitReboot,    // ERROR_SUCCESS_REBOOT_REQUIRED
+}
+
+// Exit codes of pnputil.
+var PnpUtilExitCodes = map[int32]ExitCodeMeaning{
+	0:    ExitSuccess,
+	259:  ExitSkipped, // ERROR_NO_MORE_ITEMS, the package was added but no device was updated
+	1641: ExitReboot,  // ERROR_SUCCESS_REBOOT_INITIATED
+	3010: ExitReboot,  // ERROR_SUCCESS_REBOOT_REQUIRED
+}
+
+type OutputRule struct {
+	Pattern string        `json:"pattern"` // Regular expression matched against each line
+	Source  string        `json:"source"`  // [SourceStdout], [SourceStderr], or the path of a log file written by the installer
+	Outcome OutputOutcome `json:"outcome"`
+}
+
+const (
+	SourceStdout = "stdout"
+	SourceStderr = "stderr"
+)
+
+type OutputOutcome string
+
+const (
+	OutputSuccess OutputOutcome = "success"
+	OutputFailure OutputOutcome = "failure"
+	OutputSkipped OutputOutcome = "skipped"
+)
@@ -0,0 +1,37 @@
+package storage
+
+import "testing"
+
+func TestExitCodeMeaning(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver Driver
+		code   int32
+		want   ExitCodeMeaning
+	}{
+		{"executable success", Driver{}, 0, ExitSuccess},
+		{"executable reboot", Driver{Kind: KindExecutable}, 3010, ExitReboot},
+		{"executable unknown", Driver{Kind: KindExecutable}, 1603, ExitFatal},
+		{"msi success", Driver{Kind: KindMsi}, 0, ExitSuccess},
+		{"msi failure", Driver{Kind: KindMsi}, 1603, ExitFatal},
+		{"msi already running", Driver{Kind: KindMsi}, 1618, ExitRetryable},
+		{"msi other version", Driver{Kind: KindMsi}, 1638, ExitSkipped},
+		{"msi reboot initiated", Driver{Kind: KindMsi}, 1641, ExitReboot},
+		{"msi reboot required", Driver{Kind: KindMsi}, 3010, ExitReboot},
+		{"msi unknown", Driver{Kind: KindMsi}, 1619, ExitFatal},
+		{"inf success", Driver{Kind: KindInf}, 0, ExitSuccess},
+		{"inf no device", Driver{Kind: KindInf}, 259, ExitSkipped},
+		{"inf reboot initiated", Driver{Kind: KindInf}, 1641, ExitReboot},
+		{"inf reboot required", Driver{Kind: KindInf}, 3010, ExitReboot},
+		{"inf already running", Driver{Kind: KindInf}, 1618, ExitFatal},
+		{"allowed code", Driver{Kind: KindMsi, AllowRtCodes: []int32{1603}}, 1603, ExitSuccess},
+		{"driver exit code", Driver{Kind: KindInf, AllowRtCodes: []int32{259}, ExitCodes: map[int32]ExitCodeMeaning{259: ExitRetryable}}, 259, ExitRetryable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.driver.ExitCodeMeaning(tt.code); got != tt.want {
+				t.Errorf("ExitCodeMeaning(%d) = %q, want %q", tt.code, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// Runs the managers from concurrent goroutines, as the frontend and the executor do, to be checked with -race.
+func TestManagersConcurrentAccess(t *testing.T) {
+	dir := t.TempDir()
+	groups := &DriverGroupManager{Path: filepath.Join(dir, "groups.json")}
+	settings := &AppSettingManager{Path: filepath.Join(dir, "setting.json")}
+	profiles := &ProfileManager{Path: filepath.Join(dir, "profiles.json"), Groups: groups, Settings: settings}
+
+	groupId, err := groups.Add(DriverGroup{Name: "network", Drivers: []Driver{{Name: "driver"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	profileId, err := profiles.Add(Profile{Name: "profile", Groups: []string{groupId}, SetPassword: true, Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(4)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				group, err := groups.Get(groupId)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				group.Name = "renamed"
+				if err := groups.Update(group); err != nil {
+					t.Error(err)
+				}
+				if _, err := groups.Read(); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if _, err := profiles.Apply(profileId); err != nil {
+					t.Error(err)
+				}
+				if _, err := profiles.Active(); err != nil {
+					t.Error(err)
+				}
+				profiles.Deactivate()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				setting, err := settings.Read()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				settings.Override(setting)
+				settings.Restore()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if _, err := profiles.Read(); err != nil {
+					t.Error(err)
+				}
+				if _, err := groups.IndexOf(groupId); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if group, err := groups.Get(groupId); err != nil || group.Name != "renamed" {
+		t.Errorf("Get() = %q, %v, want the renamed group", group.Name, err)
+	}
+}
@@ -0,0 +1,290 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type ProfileManager struct {
+	Path     string
+	Groups   *DriverGroupManager
+	Settings *AppSettingManager
+	// Name or ID of the profile passed in through the command line
+	Startup  string
+	active   *Profile // Profile applied in the session
+	profiles []Profile
+	fstat    os.FileInfo
+	mu       sync.Mutex // Guards the profiles and the active profile
+}
+
+func (m *ProfileManager) Read() ([]Profile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.read(); err != nil {
+		return nil, err
+	}
+	return slices.Clone(m.profiles), nil
+}
+
+// Reloads the profiles if the file has changed, the caller must hold the lock.
+func (m *ProfileManager) read() error {
+	if m.fstat == nil || m.modified() {
+		var profiles []Profile
+
+		if _, err := os.Stat(m.Path); err != nil {
+			os.WriteFile(m.Path, []byte("[]"), os.ModePerm)
+		}
+
+		bytes, err := os.ReadFile(m.Path)
+		if err != nil {
+			return err
+		}
+
+		if err := json.Unmarshal(bytes, &profiles); err != nil {
+			return err
+		}
+
+		m.profiles = profiles
+	}
+	return nil
+}
+
+func (m *ProfileManager) write() error {
+	bytes, err := json.Marshal(m.profiles)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(m.Path, bytes, os.ModePerm); err == nil {
+		m.fstat, _ = os.Stat(m.Path)
+		return nil
+	} else {
+		return err
+	}
+}
+
+func (m *ProfileManager) modified() bool {
+	if m.fstat == nil {
+		return false
+	}
+
+	if stat, err := os.Stat(m.Path); err != nil {
+		return false
+	} else {
+		return stat.ModTime().After(m.fstat.ModTime())
+	}
+}
+
+func (m *ProfileManager) IndexOf(profileId string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.indexOf(profileId)
+}
+
+func (m *ProfileManager) indexOf(profileId string) (int, error) {
+	index := slices.IndexFunc(m.profiles, func(p Profile) bool {
+		return p.Id == profileId
+	})
+
+	if index == -1 {
+		return -1, errors.New("storage: no profile with the same ID was found")
+	}
+	return index, nil
+}
+
+func (m *ProfileManager) Get(id string) (Profile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.get(id)
+}
+
+func (m *ProfileManager) get(id string) (Profile, error) {
+	if err := m.read(); err != nil {
+		return Profile{}, err
+	}
+
+	if index, err := m.indexOf(id); err != nil {
+		return Profile{}, err
+	} else {
+		return m.profiles[index], nil
+	}
+}
+
+// Find looks up a profile by its ID, or by its name case-insensitively.
+func (m *ProfileManager) Find(nameOrId string) (Profile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.read(); err != nil {
+		return Profile{}, err
+	}
+
+	for _, profile := range m.profiles {
+		if profile.Id == nameOrId || strings.EqualFold(profile.Name, nameOrId) {
+			return profile, nil
+		}
+	}
+	return Profile{}, errors.New("storage: no profile with the same name or ID was found")
+}
+
+func (m *ProfileManager) Add(profile Profile) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for profile.Id = ""; profile.Id == ""; {
+		if id, err := randomString(4); err != nil {
+			continue
+		} else if idx, _ := m.indexOf(id); idx != -1 {
+			continue
+		} else {
+			profile.Id = id
+		}
+	}
+
+	m.profiles = append(m.profiles, profile)
+	return profile.Id, m.write()
+}
+
+func (m *ProfileManager) Update(profile Profile) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if index, err := m.indexOf(profile.Id); err != nil {
+		return err
+	} else {
+		m.profiles[index] = profile
+		return m.write()
+	}
+}
+
+func (m *ProfileManager) Remove(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if index, err := m.indexOf(id); err != nil {
+		return err
+	} else {
+		m.profiles = append(m.profiles[:index], m.profiles[index+1:]...)
+		return m.write()
+	}
+}
+
+// Apply overrides the application setting with the profile for the rest of the session, leaving the
+// setting file untouched, and returns the profile with group IDs that no longer exist filtered out.
+func (m *ProfileManager) Apply(id string) (Profile, error) {
+	if m.Settings == nil {
+		return Profile{}, errors.New("storage: no setting manager attached")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	profile, err := m.get(id)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	// applied over the setting written rather than the one overridden
+	setting, err := m.Settings.written()
+	if err != nil {
+		return Profile{}, err
+	}
+	m.Settings.Override(profile.Override(setting))
+
+	m.active = &profile
+	return m.existingGroups(profile)
+}
+
+// Active returns the profile applied in the session, or an empty profile if none is,
+// including after the setting is written as it ends the override.
+func (m *ProfileManager) Active() (Profile, error) {
+	m.mu.Lock()
+	active := m.active
+	m.mu.Unlock()
+
+	if active == nil || m.Settings == nil || !m.Settings.overridden() {
+		return Profile{}, nil
+	}
+	return m.existingGroups(*active)
+}
+
+// Deactivate restores the application setting overridden by the applied profile.
+func (m *ProfileManager) Deactivate() {
+	m.mu.Lock()
+	m.active = nil
+	m.mu.Unlock()
+
+	if m.Settings != nil {
+		m.Settings.Restore()
+	}
+}
+
+// Returns the profile with group IDs that no longer exist filtered out.
+func (m *ProfileManager) existingGroups(profile Profile) (Profile, error) {
+	if m.Groups == nil {
+		return profile, nil
+	}
+
+	if _, err := m.Groups.Read(); err != nil {
+		return Profile{}, err
+	}
+
+	profile.Groups = slices.DeleteFunc(slices.Clone(profile.Groups), func(groupId string) bool {
+		_, err := m.Groups.IndexOf(groupId)
+		return err != nil
+	})
+	return profile, nil
+}
+
+// StartupProfile applies the profile given through the command line.
+// An empty profile is returned if no profile was specified.
+func (m *ProfileManager) StartupProfile() (Profile, error) {
+	if m.Startup == "" {
+		return Profile{}, nil
+	}
+
+	if profile, err := m.Find(m.Startup); err != nil {
+		return Profile{}, err
+	} else {
+		return m.Apply(profile.Id)
+	}
+}
+
+type Profile struct {
+	Id                 string         `json:"id"`
+	Name               string         `json:"name"`
+	Groups             []string       `json:"groups"`
+	CreatePartition    bool           `json:"create_partition"`
+	SetPassword        bool           `json:"set_password"`
+	Password           string         `json:"password"`
+	MaxConcurrency     *int           `json:"max_concurrency,omitempty"`
+	SuccessAction      *SuccessAction `json:"success_action,omitempty"`
+	SuccessActionDelay *int           `json:"success_action_delay,omitempty"`
+}
+
+// Override returns a copy of the setting with the values specified by the profile.
+func (p Profile) Override(setting AppSetting) AppSetting {
+	setting.CreatePartition = p.CreatePartition
+	setting.SetPassword = p.SetPassword
+	if p.SetPassword {
+		setting.Password = p.Password
+	}
+
+	if p.MaxConcurrency != nil {
+		setting.MaxConcurrency = *p.MaxConcurrency
+	}
+	if p.SuccessAction != nil {
+		setting.SuccessAction = *p.SuccessAction
+	}
+	if p.SuccessActionDelay != nil {
+		setting.SuccessActionDelay = *p.SuccessActionDelay
+	}
+	return setting
+}
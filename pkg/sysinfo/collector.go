@@ -0,0 +1,183 @@
+package sysinfo
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTTL          = 5 * time.Minute
+	defaultQueryTimeout = 10 * time.Second
+)
+
+// Collector runs queries concurrently, and caches their results for reuse.
+//
+// A query exceeding the timeout is not cancelled, as WMI queries cannot be. It keeps running in
+// the background on its own connection, and later collections wait for it until it has run for
+// the timeout. After that it is abandoned and the next collection starts the query again, so that
+// a hanging query, e.g. on a failing disk, is retried at most once per timeout.
+type Collector struct {
+	TTL     time.Duration // Duration a result is reused, [defaultTTL] if zero
+	Timeout time.Duration // Maximum duration waited for each query, [defaultQueryTimeout] if zero
+
+	mu      sync.Mutex
+	entries map[string]*collectorEntry
+}
+
+type collectorEntry struct {
+	done    chan struct{} // Closed once the query has returned
+	started time.Time
+	value   any
+	err     error
+	fetched time.Time
+}
+
+// Snapshot is the hardware summary collected from the queries succeeded, with the errors of the others.
+type Snapshot struct {
+	Summary Summary           `json:"summary"`
+	Errors  map[string]string `json:"errors"` // Errors of the failed or timed out queries, keyed by the WMI class
+}
+
+// Invalidates all cached results. Running queries are left to complete.
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		select {
+		case <-entry.done:
+			delete(c.entries, key)
+		default:
+		}
+	}
+}
+
+// Returns the timeout of c, [defaultQueryTimeout] if unset.
+func (c *Collector) timeout() time.Duration {
+	if c.Timeout == 0 {
+		return defaultQueryTimeout
+	}
+	return c.Timeout
+}
+
+// Returns the entry of key, starting the query if no fresh entry exists, or no entry running for
+// less than the timeout.
+func (c *Collector) entry(key string, query func() (any, error)) *collectorEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]*collectorEntry)
+	}
+
+	ttl := c.TTL
+	if ttl == 0 {
+		ttl = defaultTTL
+	}
+
+	if entry, ok := c.entries[key]; ok {
+		select {
+		case <-entry.done:
+			// failed queries are retried
+			if entry.err == nil && time.Since(entry.fetched) < ttl {
+				return entry
+			}
+		default:
+			// a stuck query is abandoned, and left to complete into its own entry
+			if time.Since(entry.started) < c.timeout() {
+				return entry
+			}
+		}
+	}
+
+	entry := &collectorEntry{done: make(chan struct{}), started: time.Now()}
+	c.entries[key] = entry
+	go func() {
+		defer close(entry.done)
+		entry.value, entry.err = query()
+		entry.fetched = time.Now()
+	}()
+	return entry
+}
+
+// Returns the result of query from the cache of c, or runs the query and waits for it up to the timeout.
+func collect[T any](c *Collector, key string, query func() (T, error)) (T, error) {
+	entry := c.entry(key, func() (any, error) { return query() })
+
+	var zero T
+	select {
+	case <-entry.done:
+		if entry.err != nil {
+			return zero, entry.err
+		}
+		return entry.value.(T), nil
+	case <-time.After(c.timeout()):
+		return zero, errors.New("sysinfo: query timed out")
+	}
+}
+
+// Returns the collector attached, or a new one which caches nothing beyond the call.
+func (i SysInfo) collector() *Collector {
+	if i.Collector == nil {
+		return &Collector{}
+	}
+	return i.Collector
+}
+
+// Collects the hardware information concurrently, reusing the cached results.
+func (i SysInfo) snapshot(c *Collector) (Snapshot, error) {
+	var hw Hardware
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make(map[string]string)
+
+	run := func(class string, query func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := query(); err != nil {
+				mu.Lock()
+				errs[class] = err.Error()
+				mu.Unlock()
+			}
+		}()
+	}
+
+	run("Win32_BaseBoard", func() (err error) { hw.Motherboards, err = collect(c, "Win32_BaseBoard", i.MotherboardInfo); return })
+	run("Win32_Processor", func() (err error) { hw.Cpus, err = collect(c, "Win32_Processor", i.CpuInfo); return })
+	run("Win32_PhysicalMemory", func() (err error) { hw.Memory, err = collect(c, "Win32_PhysicalMemory", i.MemoryInfo); return })
+	run("Win32_VideoController", func() (err error) { hw.Gpus, err = collect(c, "Win32_VideoController", i.GpuInfo); return })
+	run("Win32_NetworkAdapter", func() (err error) { hw.Nics, err = collect(c, "Win32_NetworkAdapter", i.NicInfo); return })
+	run("Win32_DiskDrive", func() (err error) { hw.Disks, err = collect(c, "Win32_DiskDrive", i.DiskInfo); return })
+
+	// best effort, as they are not available on every system
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		hw.PhysicalDisks, _ = collect(c, "MSFT_PhysicalDisk", i.PhysicalDiskInfo)
+	}()
+	go func() {
+		defer wg.Done()
+		hw.VideoMemory, _ = collect(c, "video_memory", func() (map[string]uint64, error) { return videoMemory(), nil })
+	}()
+
+	wg.Wait()
+
+	if len(errs) == 6 {
+		return Snapshot{Errors: errs}, errors.New("sysinfo: no hardware information collected")
+	}
+
+	summary := Summarise(hw, i.nicFilter())
+
+	// display adapters without a driver are named generically, e.g. Microsoft Basic Display Adapter
+	if resolver, err := i.idResolver(); err == nil {
+		for j, gpu := range hw.Gpus {
+			if name, ok := resolver.Resolve(gpu.PNPDeviceID); ok {
+				summary.Gpus[j].Model = joinNonEmpty(name.Vendor, name.Device)
+			}
+		}
+	}
+	return Snapshot{Summary: summary, Errors: errs}, nil
+}
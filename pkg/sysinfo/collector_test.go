@@ -0,0 +1,72 @@
+package sysinfo
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCollectRetriesStuckQuery(t *testing.T) {
+	c := &Collector{Timeout: 20 * time.Millisecond}
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	defer close(release)
+	query := func() (int, error) {
+		if calls.Add(1) == 1 {
+			<-release
+		}
+		return 1, nil
+	}
+
+	if _, err := collect(c, "key", query); err == nil {
+		t.Fatal("collect() of a stuck query: want a timeout error")
+	}
+
+	// the stuck query has run for the timeout, so it is abandoned and started again
+	got, err := collect(c, "key", query)
+	if err != nil || got != 1 {
+		t.Fatalf("collect() after the timeout = %v, %v, want 1, nil", got, err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("query ran %d times, want 2", n)
+	}
+
+	// the result of the retry is cached
+	if _, err := collect(c, "key", query); err != nil {
+		t.Fatal(err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("query ran %d times after a cached collection, want 2", n)
+	}
+}
+
+func TestCollectWaitsForRunningQuery(t *testing.T) {
+	c := &Collector{Timeout: time.Second}
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	query := func() (int, error) {
+		calls.Add(1)
+		<-release
+		return 1, nil
+	}
+
+	results := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := collect(c, "key", query)
+			results <- err
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	for range 2 {
+		if err := <-results; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("query ran %d times, want 1", n)
+	}
+}
@@ -0,0 +1,122 @@
+package sysinfo
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/yusufpapurcu/wmi"
+)
+
+// Error codes of ConfigManagerErrorCode, named after the CM_PROB constants of cfg.h.
+const (
+	cmProbNotConfigured = 1  // CM_PROB_NOT_CONFIGURED, the device has no configuration, usually no driver
+	cmProbReinstall     = 18 // CM_PROB_REINSTALL, the drivers must be reinstalled
+	cmProbFailedInstall = 28 // CM_PROB_FAILED_INSTALL, the drivers are not installed
+	cmProbPhantom       = 45 // CM_PROB_PHANTOM, the device is not connected
+)
+
+// Error codes of devices without a working driver installed, which installing a driver fixes.
+// Other codes, such as a disabled device or missing resources, are reported but not counted as a missing driver.
+//
+// Devices without a driver, shown as unknown devices, usually have no class GUID either,
+// but the error code is the reliable signal so the class is not considered.
+var missingDriverCodes = []uint32{cmProbNotConfigured, cmProbReinstall, cmProbFailedInstall}
+
+// Explanations of the error codes of devices, as listed by Device Manager.
+//
+// See: https://learn.microsoft.com/en-us/windows-hardware/drivers/install/device-manager-error-messages
+var errorExplanations = map[uint32]string{
+	1:  "The device is not configured correctly.",
+	3:  "The driver may be corrupted, or the system is running low on memory.",
+	9:  "The device does not have a valid hardware ID.",
+	10: "The device cannot start.",
+	12: "The device cannot find enough free resources.",
+	14: "The device cannot work properly until the computer is restarted.",
+	16: "Not all resources used by the device can be identified.",
+	18: "The drivers of the device need to be reinstalled.",
+	19: "The configuration of the device in the registry is incomplete or damaged.",
+	21: "The device is being removed.",
+	22: "The device is disabled.",
+	24: "The device is not present, not working properly, or does not have all its drivers installed.",
+	28: "The drivers of the device are not installed.",
+	29: "The device is disabled because the firmware did not give it the required resources.",
+	31: "The drivers required for the device cannot be loaded.",
+	32: "The driver service of the device is disabled.",
+	33: "The resources required for the device cannot be determined.",
+	34: "The settings of the device cannot be determined.",
+	35: "The system firmware does not include enough information to configure the device.",
+	36: "The device requests a PCI interrupt but is configured for an ISA interrupt, or the other way round.",
+	37: "The driver of the device failed to initialise.",
+	38: "A previous instance of the driver is still in memory.",
+	39: "The driver of the device may be corrupted or missing.",
+	40: "The service key of the driver is missing or invalid in the registry.",
+	41: "The driver was loaded but the device cannot be found.",
+	42: "A duplicate device is already running.",
+	43: "The device was stopped because it reported problems.",
+	44: "The device was shut down by an application or service.",
+	45: "The device is not connected.",
+	46: "The device is not available as the system is shutting down.",
+	47: "The device was prepared for safe removal but not removed.",
+	48: "The driver of the device is blocked as it is known to have problems with Windows.",
+	49: "New devices cannot be started as the system hive is too large.",
+	50: "Not all properties of the device can be applied.",
+	51: "The device is waiting on other devices to start.",
+	52: "The digital signature of the drivers cannot be verified.",
+	53: "The device is reserved for the kernel debugger.",
+	54: "The device failed and is being reset.",
+}
+
+// ProblemDevice is a device in an error state, shown with a warning sign in Device Manager.
+type ProblemDevice struct {
+	DeviceID      string   `json:"deviceId"`
+	Name          string   `json:"name"`
+	ClassGuid     string   `json:"classGuid"`
+	HardwareIDs   []string `json:"hardwareIds"`
+	Status        string   `json:"status"`
+	ErrorCode     uint32   `json:"errorCode"`
+	Explanation   string   `json:"explanation"`
+	MissingDriver bool     `json:"missingDriver"` // Whether the device has no driver installed, rather than a failed one
+}
+
+// ProblemDevices returns the devices reporting an error, including unknown devices without a driver.
+// Devices that are not connected are left out.
+func (i SysInfo) ProblemDevices() ([]ProblemDevice, error) {
+	var cls []Win32_PnPEntity
+	q := wmi.CreateQuery(&cls, "WHERE ConfigManagerErrorCode <> 0")
+	if err := wmiQuery(q, &cls); err != nil {
+		return nil, err
+	}
+	return problemDevices(cls), nil
+}
+
+// Converts the Plug and Play entities reporting an error, leaving out those that are not connected.
+func problemDevices(entities []Win32_PnPEntity) []ProblemDevice {
+	devices := make([]ProblemDevice, 0, len(entities))
+	for _, entity := range entities {
+		if entity.ConfigManagerErrorCode == cmProbPhantom {
+			continue
+		}
+
+		explanation, ok := errorExplanations[entity.ConfigManagerErrorCode]
+		if !ok {
+			explanation = fmt.Sprintf("The device reported error code %d.", entity.ConfigManagerErrorCode)
+		}
+
+		name := entity.Name
+		if name == "" {
+			name = entity.PNPDeviceID
+		}
+
+		devices = append(devices, ProblemDevice{
+			DeviceID:      entity.PNPDeviceID,
+			Name:          name,
+			ClassGuid:     entity.ClassGuid,
+			HardwareIDs:   entity.ids(),
+			Status:        entity.Status,
+			ErrorCode:     entity.ConfigManagerErrorCode,
+			Explanation:   explanation,
+			MissingDriver: slices.Contains(missingDriverCodes, entity.ConfigManagerErrorCode),
+		})
+	}
+	return devices
+}
@@ -0,0 +1,101 @@
+package sysinfo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProblemDevices(t *testing.T) {
+	entities := []Win32_PnPEntity{
+		// unknown device, without a class as no driver matched it
+		{
+			Name:                   "",
+			PNPDeviceID:            `PCI\VEN_8086&DEV_A0E8&SUBSYS_380117AA&REV_20\3&11583659&0&A8`,
+			ConfigManagerErrorCode: 28,
+			Status:                 "Error",
+		},
+		{
+			Name:                   "Realtek PCIe GbE Family Controller",
+			PNPDeviceID:            `PCI\VEN_10EC&DEV_8168&SUBSYS_86771043&REV_15\01000000684CE00000`,
+			ClassGuid:              "{4d36e972-e325-11ce-bfc1-08002be10318}",
+			HardwareID:             []string{`PCI\VEN_10EC&DEV_8168&SUBSYS_86771043&REV_15`, `PCI\VEN_10EC&DEV_8168`},
+			CompatibleID:           []string{`PCI\CC_020000`},
+			ConfigManagerErrorCode: 18,
+			Status:                 "Error",
+		},
+		// a disabled device has a driver, whatever its class
+		{
+			Name:                   "Intel(R) Wireless Bluetooth(R)",
+			PNPDeviceID:            `USB\VID_8087&PID_0026\5&2A4F5C0&0&10`,
+			ConfigManagerErrorCode: 22,
+			Status:                 "Error",
+		},
+		{
+			Name:                   "USB Mass Storage Device",
+			PNPDeviceID:            `USB\VID_0781&PID_5581\4C530001130814115421`,
+			ClassGuid:              "{36fc9e60-c465-11cf-8056-444553540000}",
+			ConfigManagerErrorCode: 45,
+		},
+		{
+			Name:                   "Sensor",
+			PNPDeviceID:            `ACPI\INT33D6\1`,
+			ClassGuid:              "{5175d334-c371-4806-b3ba-71fd53c9258d}",
+			ConfigManagerErrorCode: 99,
+			Status:                 "Degraded",
+		},
+	}
+
+	want := []ProblemDevice{
+		{
+			DeviceID: `PCI\VEN_8086&DEV_A0E8&SUBSYS_380117AA&REV_20\3&11583659&0&A8`,
+			Name:     `PCI\VEN_8086&DEV_A0E8&SUBSYS_380117AA&REV_20\3&11583659&0&A8`,
+			HardwareIDs: []string{
+				`PCI\VEN_8086&DEV_A0E8&SUBSYS_380117AA&REV_20`, `PCI\VEN_8086&DEV_A0E8&SUBSYS_380117AA`,
+				`PCI\VEN_8086&DEV_A0E8&REV_20`, `PCI\VEN_8086&DEV_A0E8`,
+			},
+			Status:        "Error",
+			ErrorCode:     28,
+			Explanation:   "The drivers of the device are not installed.",
+			MissingDriver: true,
+		},
+		{
+			DeviceID:  `PCI\VEN_10EC&DEV_8168&SUBSYS_86771043&REV_15\01000000684CE00000`,
+			Name:      "Realtek PCIe GbE Family Controller",
+			ClassGuid: "{4d36e972-e325-11ce-bfc1-08002be10318}",
+			HardwareIDs: []string{
+				`PCI\VEN_10EC&DEV_8168&SUBSYS_86771043&REV_15`, `PCI\VEN_10EC&DEV_8168`, `PCI\CC_020000`,
+			},
+			Status:        "Error",
+			ErrorCode:     18,
+			Explanation:   "The drivers of the device need to be reinstalled.",
+			MissingDriver: true,
+		},
+		{
+			DeviceID:    `USB\VID_8087&PID_0026\5&2A4F5C0&0&10`,
+			Name:        "Intel(R) Wireless Bluetooth(R)",
+			HardwareIDs: []string{`USB\VID_8087&PID_0026`},
+			Status:      "Error",
+			ErrorCode:   22,
+			Explanation: "The device is disabled.",
+		},
+		{
+			DeviceID:    `ACPI\INT33D6\1`,
+			Name:        "Sensor",
+			ClassGuid:   "{5175d334-c371-4806-b3ba-71fd53c9258d}",
+			HardwareIDs: []string{`ACPI\INT33D6`},
+			Status:      "Degraded",
+			ErrorCode:   99,
+			Explanation: "The device reported error code 99.",
+		},
+	}
+
+	got := problemDevices(entities)
+	if len(got) != len(want) {
+		t.Fatalf("problemDevices() returned %d devices, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("problemDevices()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package sysinfo
+
+import (
+	"driver-box/pkg/installer"
+	"slices"
+	"strings"
+
+	"github.com/yusufpapurcu/wmi"
+)
+
+// Enumerators of software devices, which have no driver package to look for.
+var softwareEnumerators = []string{"ROOT", "SWD", "SW", "HTREE", "UMB", "STORAGE"}
+
+// DeviceMatch is a device with the local driver packages supporting it.
+type DeviceMatch struct {
+	DeviceID string               `json:"deviceId"`
+	Name     string               `json:"name"`
+	Matches  []installer.InfMatch `json:"matches"`
+}
+
+// DriverMatchReport lists the devices supported by the local driver packages, and those with no match.
+type DriverMatchReport struct {
+	Matched   []DeviceMatch `json:"matched"`
+	Unmatched []DeviceMatch `json:"unmatched"`
+	Errors    []string      `json:"errors"` // INF files that could not be parsed
+}
+
+func (i SysInfo) PnpEntityInfo() ([]Win32_PnPEntity, error) {
+	var cls []Win32_PnPEntity
+	q := wmi.CreateQuery(&cls, "")
+	if err := wmiQuery(q, &cls); err != nil {
+		return cls, err
+	}
+	return cls, nil
+}
+
+// MatchDrivers matches the hardware IDs of the devices against the INF files under the driver directory.
+func (i SysInfo) MatchDrivers() (DriverMatchReport, error) {
+	var report DriverMatchReport
+
+	index, errs := installer.ScanInfs(i.DriverDir)
+	for _, err := range errs {
+		report.Errors = append(report.Errors, err.Error())
+	}
+
+	devices, err := i.devices()
+	if err != nil {
+		return report, err
+	}
+
+	for _, device := range devices {
+		match := DeviceMatch{DeviceID: device.PNPDeviceID, Name: device.Name, Matches: index.Match(device.ids())}
+		if len(match.Matches) > 0 {
+			report.Matched = append(report.Matched, match)
+		} else {
+			report.Unmatched = append(report.Unmatched, match)
+		}
+	}
+	return report, nil
+}
+
+// Returns the hardware devices from the PnP entities, the video controllers and the network adapters,
+// without duplicates. The video controllers and network adapters cover older systems, where the
+// PnP entities do not list every device.
+func (i SysInfo) devices() ([]Win32_PnPEntity, error) {
+	entities, err := i.PnpEntityInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	gpus, err := i.GpuInfo()
+	if err != nil {
+		return nil, err
+	}
+	for _, gpu := range gpus {
+		entities = append(entities, Win32_PnPEntity{Name: gpu.Name, PNPDeviceID: gpu.PNPDeviceID})
+	}
+
+	nics, err := i.NicInfo()
+	if err != nil {
+		return nil, err
+	}
+	for _, nic := range nics {
+		entities = append(entities, Win32_PnPEntity{Name: nic.Name, PNPDeviceID: nic.PNPDeviceID})
+	}
+
+	var devices []Win32_PnPEntity
+	for _, entity := range entities {
+		enumerator, _, ok := strings.Cut(entity.PNPDeviceID, `\`)
+		if !ok || slices.Contains(softwareEnumerators, strings.ToUpper(enumerator)) {
+			continue
+		}
+		if slices.ContainsFunc(devices, func(d Win32_PnPEntity) bool { return strings.EqualFold(d.PNPDeviceID, entity.PNPDeviceID) }) {
+			continue
+		}
+		devices = append(devices, entity)
+	}
+	return devices, nil
+}
+
+// Returns the hardware and compatible IDs of the entity, derived from its instance ID if not reported.
+func (e Win32_PnPEntity) ids() []string {
+	if len(e.HardwareID) > 0 {
+		return append(slices.Clone(e.HardwareID), e.CompatibleID...)
+	}
+	return installer.DeviceIDs(e.PNPDeviceID)
+}
@@ -0,0 +1,300 @@
+package sysinfo
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IDDatabase holds the vendor, device and subsystem names of a pci.ids or usb.ids database, keyed by vendor ID.
+//
+// See: https://pci-ids.ucw.cz and https://www.linux-usb.org/usb-ids.html
+type IDDatabase map[uint16]idVendor
+
+type idVendor struct {
+	name    string
+	devices map[uint16]idDevice
+}
+
+type idDevice struct {
+	name       string
+	subsystems map[uint32]string // Keyed by the subsystem vendor ID in the high 16 bits, and the subsystem ID in the low 16 bits
+}
+
+// HardwareID is the vendor, device and subsystem IDs parsed from a PCI or USB hardware ID.
+type HardwareID struct {
+	Bus       string // PCI or USB
+	Vendor    uint16
+	Device    uint16
+	SubVendor uint16 // Zero if not specified
+	SubDevice uint16
+}
+
+// DeviceName is the vendor and model names of a device, resolved from its hardware ID.
+type DeviceName struct {
+	DeviceID  string `json:"deviceId"`
+	Name      string `json:"name"` // Name reported by Windows, which is generic for devices without a driver
+	Vendor    string `json:"vendor"`
+	Device    string `json:"device"`    // Empty if only the vendor is known
+	Subsystem string `json:"subsystem"` // Name of the board or card variant, empty if unknown
+}
+
+// IDResolver resolves the hardware IDs of PCI and USB devices to vendor and model names.
+type IDResolver struct {
+	PCI IDDatabase
+	USB IDDatabase
+}
+
+func ParseIDFile(path string) (IDDatabase, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseIDs(file)
+}
+
+// ParseIDs parses a database in the pci.ids format, which usb.ids also follows.
+// Only the vendor list is read; the class and other lists following it are skipped.
+func ParseIDs(r io.Reader) (IDDatabase, error) {
+	db := IDDatabase{}
+
+	var vendor *idVendor
+	var device *idDevice
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		switch {
+		case !strings.HasPrefix(line, "\t"):
+			device = nil
+			id, name, ok := idLine(line)
+			if !ok {
+				// beginning of the other lists, e.g. "C 00  Unclassified device"
+				vendor = nil
+				continue
+			}
+			db[id] = idVendor{name: name, devices: map[uint16]idDevice{}}
+			v := db[id]
+			vendor = &v
+		case vendor == nil:
+			continue
+		case !strings.HasPrefix(line, "\t\t"):
+			id, name, ok := idLine(line[1:])
+			if !ok {
+				device = nil
+				continue
+			}
+			vendor.devices[id] = idDevice{name: name, subsystems: map[uint32]string{}}
+			d := vendor.devices[id]
+			device = &d
+		case device != nil:
+			// subsystems of PCI devices, interfaces of USB devices are skipped
+			subVendor, rest, ok := idLine(line[2:])
+			if !ok {
+				continue
+			}
+			if subDevice, name, ok := idLine(rest); ok {
+				device.subsystems[uint32(subVendor)<<16|uint32(subDevice)] = name
+			}
+		}
+	}
+	return db, scanner.Err()
+}
+
+// Parses a line starting with a four-digit hexadecimal ID, followed by whitespace and a name.
+func idLine(line string) (uint16, string, bool) {
+	if len(line) < 6 || (line[4] != ' ' && line[4] != '\t') {
+		return 0, "", false
+	}
+
+	id, err := strconv.ParseUint(line[:4], 16, 16)
+	if err != nil {
+		return 0, "", false
+	}
+	return uint16(id), strings.TrimSpace(line[5:]), true
+}
+
+// Lookup returns the names of a vendor, device and subsystem, which are empty if not found.
+func (db IDDatabase) Lookup(id HardwareID) (vendor, device, subsystem string) {
+	v, ok := db[id.Vendor]
+	if !ok {
+		return "", "", ""
+	}
+	d, ok := v.devices[id.Device]
+	if !ok {
+		return v.name, "", ""
+	}
+	return v.name, d.name, d.subsystems[uint32(id.SubVendor)<<16|uint32(id.SubDevice)]
+}
+
+// ParseHardwareID parses a PCI hardware ID, e.g. PCI\VEN_10DE&DEV_2504&SUBSYS_397D1462&REV_A1,
+// or a USB one, e.g. USB\VID_046D&PID_C52B&REV_1211. It reports false for other buses, or IDs
+// without a vendor and a device.
+func ParseHardwareID(id string) (HardwareID, bool) {
+	bus, rest, ok := strings.Cut(id, `\`)
+	if !ok {
+		return HardwareID{}, false
+	}
+
+	hwid := HardwareID{Bus: strings.ToUpper(bus)}
+	var vendorKey, deviceKey string
+	switch hwid.Bus {
+	case "PCI":
+		vendorKey, deviceKey = "VEN_", "DEV_"
+	case "USB":
+		vendorKey, deviceKey = "VID_", "PID_"
+	default:
+		return HardwareID{}, false
+	}
+
+	// instance IDs are followed by the instance path
+	rest, _, _ = strings.Cut(rest, `\`)
+
+	var hasVendor, hasDevice bool
+	for _, field := range strings.Split(strings.ToUpper(rest), "&") {
+		switch {
+		case strings.HasPrefix(field, vendorKey):
+			hwid.Vendor, hasVendor = parseHex16(field[len(vendorKey):])
+		case strings.HasPrefix(field, deviceKey):
+			hwid.Device, hasDevice = parseHex16(field[len(deviceKey):])
+		case strings.HasPrefix(field, "SUBSYS_") && len(field) == len("SUBSYS_")+8:
+			// subsystem ID followed by the subsystem vendor ID
+			subDevice, ok1 := parseHex16(field[7:11])
+			subVendor, ok2 := parseHex16(field[11:])
+			if ok1 && ok2 {
+				hwid.SubVendor, hwid.SubDevice = subVendor, subDevice
+			}
+		}
+	}
+	return hwid, hasVendor && hasDevice
+}
+
+func parseHex16(s string) (uint16, bool) {
+	if len(s) != 4 {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(s, 16, 16)
+	return uint16(n), err == nil
+}
+
+// Resolve returns the names of the device with the hardware ID. It reports false if the vendor is unknown.
+func (r IDResolver) Resolve(id string) (DeviceName, bool) {
+	hwid, ok := ParseHardwareID(id)
+	if !ok {
+		return DeviceName{}, false
+	}
+
+	db := r.PCI
+	if hwid.Bus == "USB" {
+		db = r.USB
+	}
+
+	name := DeviceName{DeviceID: id}
+	name.Vendor, name.Device, name.Subsystem = db.Lookup(hwid)
+	return name, name.Vendor != ""
+}
+
+// Parsed databases by path, reloaded once the file is modified, e.g. replaced by an import.
+var idCache = struct {
+	sync.Mutex
+	entries map[string]idCacheEntry
+}{entries: map[string]idCacheEntry{}}
+
+type idCacheEntry struct {
+	modTime time.Time
+	db      IDDatabase
+}
+
+func loadIDDatabase(path string) (IDDatabase, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	idCache.Lock()
+	defer idCache.Unlock()
+
+	if entry, ok := idCache.entries[path]; ok && entry.modTime.Equal(info.ModTime()) {
+		return entry.db, nil
+	}
+
+	db, err := ParseIDFile(path)
+	if err != nil {
+		return nil, err
+	}
+	idCache.entries[path] = idCacheEntry{modTime: info.ModTime(), db: db}
+	return db, nil
+}
+
+// Returns the resolver of the pci.ids and usb.ids databases under the ID directory.
+// A missing database is left empty, unless both are missing.
+func (i SysInfo) idResolver() (IDResolver, error) {
+	var resolver IDResolver
+	var errs []error
+
+	for _, db := range []struct {
+		name string
+		dst  *IDDatabase
+	}{
+		{"pci.ids", &resolver.PCI},
+		{"usb.ids", &resolver.USB},
+	} {
+		var err error
+		if *db.dst, err = loadIDDatabase(filepath.Join(i.IDDir, db.name)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 2 {
+		return resolver, errors.Join(append(errs, errors.New("sysinfo: no ID database available"))...)
+	}
+	return resolver, nil
+}
+
+// ResolveDeviceName returns the vendor and model names of a PCI or USB hardware ID.
+func (i SysInfo) ResolveDeviceName(id string) (DeviceName, error) {
+	resolver, err := i.idResolver()
+	if err != nil {
+		return DeviceName{}, err
+	}
+
+	name, ok := resolver.Resolve(id)
+	if !ok {
+		return name, errors.New("sysinfo: unknown device")
+	}
+	return name, nil
+}
+
+// DeviceNames returns the vendor and model names of the PCI and USB devices found in the ID databases.
+func (i SysInfo) DeviceNames() ([]DeviceName, error) {
+	resolver, err := i.idResolver()
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := i.devices()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []DeviceName
+	for _, device := range devices {
+		if name, ok := resolver.Resolve(device.PNPDeviceID); ok {
+			name.Name = device.Name
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,206 @@
+package sysinfo
+
+import (
+	"cmp"
+	"driver-box/pkg/installer"
+	"driver-box/pkg/storage"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/yusufpapurcu/wmi"
+)
+
+type DriverState string
+
+const (
+	UpToDate     DriverState = "up_to_date"
+	Outdated     DriverState = "outdated"      // The installed driver is older than the package
+	Newer        DriverState = "newer"         // The installed driver is newer than the package
+	NotInstalled DriverState = "not_installed" // The device has no driver installed
+)
+
+// DriverComparison compares the driver installed for a device with a driver package in the groups supporting it,
+// or the product installed by an executable or MSI driver in the groups with the version of the driver.
+type DriverComparison struct {
+	DeviceID         string      `json:"deviceId"`    // Empty for products
+	DeviceName       string      `json:"deviceName"`  // Empty for products
+	ProductName      string      `json:"productName"` // Product name of an executable or MSI driver, empty for devices
+	GroupId          string      `json:"groupId"`
+	DriverId         string      `json:"driverId"`
+	DriverName       string      `json:"driverName"`
+	InstalledVersion string      `json:"installedVersion"`
+	InstalledDate    time.Time   `json:"installedDate"`
+	InstalledInf     string      `json:"installedInf"`
+	Provider         string      `json:"provider"` // Provider of the installed driver, or publisher of the installed product
+	PackageVersion   string      `json:"packageVersion"`
+	PackageDate      string      `json:"packageDate"`
+	State            DriverState `json:"state"`
+}
+
+func (i SysInfo) SignedDriverInfo() ([]Win32_PnPSignedDriver, error) {
+	var cls []Win32_PnPSignedDriver
+	q := wmi.CreateQuery(&cls, "")
+	if err := wmiQuery(q, &cls); err != nil {
+		return cls, err
+	}
+	return cls, nil
+}
+
+// CompareDrivers compares the drivers installed for the devices with the INF drivers in the groups supporting them,
+// including devices without a driver installed. The products installed by the executable and MSI drivers
+// in the groups are compared with the versions of the drivers, see [compareProducts].
+func (i SysInfo) CompareDrivers() ([]DriverComparison, error) {
+	if i.Groups == nil {
+		return nil, errors.New("sysinfo: no driver group manager attached")
+	}
+
+	groups, err := i.Groups.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	type source struct {
+		group  storage.DriverGroup
+		driver storage.Driver
+	}
+
+	// INF drivers indexed by the paths of their files
+	index := make(installer.InfIndex)
+	sources := make(map[string]source)
+	for _, group := range groups {
+		for _, driver := range group.Drivers {
+			if driver.Kind != storage.KindInf {
+				continue
+			}
+
+			path := driver.Path
+			if i.Resolve != nil {
+				path = i.Resolve(path)
+			}
+			if _, ok := sources[path]; ok {
+				continue
+			}
+			if inf, err := installer.ParseInfFile(path); err == nil {
+				index.Add(path, inf)
+				sources[path] = source{group, driver}
+			}
+		}
+	}
+
+	var comparisons []DriverComparison
+	compare := func(comparison DriverComparison, ids []string) {
+		for _, match := range index.Match(ids) {
+			src := sources[match.Package.Path]
+
+			c := comparison
+			c.GroupId, c.DriverId, c.DriverName = src.group.Id, src.driver.Id, src.driver.Name
+			c.PackageVersion, c.PackageDate = match.Package.Version, match.Package.Date
+			if c.State == "" {
+				switch installer.CompareVersions(c.InstalledVersion, c.PackageVersion) {
+				case -1:
+					c.State = Outdated
+				case 1:
+					c.State = Newer
+				default:
+					c.State = UpToDate
+				}
+			}
+			comparisons = append(comparisons, c)
+		}
+	}
+
+	signed, err := i.SignedDriverInfo()
+	if err != nil {
+		return nil, err
+	}
+	for _, driver := range signed {
+		if driver.DeviceID == "" || driver.DriverVersion == "" {
+			continue
+		}
+
+		var ids []string
+		for _, id := range append([]string{driver.HardWareID, driver.CompatID}, installer.DeviceIDs(driver.DeviceID)...) {
+			if id != "" && !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+
+		compare(DriverComparison{
+			DeviceID:         driver.DeviceID,
+			DeviceName:       driver.DeviceName,
+			InstalledVersion: driver.DriverVersion,
+			InstalledDate:    driver.DriverDate,
+			InstalledInf:     driver.InfName,
+			Provider:         driver.DriverProviderName,
+		}, ids)
+	}
+
+	problems, err := i.ProblemDevices()
+	if err != nil {
+		return nil, err
+	}
+	for _, device := range problems {
+		if device.MissingDriver {
+			compare(DriverComparison{DeviceID: device.DeviceID, DeviceName: device.Name, State: NotInstalled}, device.HardwareIDs)
+		}
+	}
+
+	products, err := i.InstalledProducts()
+	if err != nil {
+		return nil, err
+	}
+	return append(comparisons, compareProducts(groups, products)...), nil
+}
+
+// Compares the executable and MSI drivers with the installed products of the same name as their product name,
+// which is read into their metadata. The newest of the products of the same name is compared.
+// Drivers without a product name cannot be compared, and a product name with the same version is only compared once.
+func compareProducts(groups []storage.DriverGroup, products []InstalledProduct) []DriverComparison {
+	var comparisons []DriverComparison
+	compared := make(map[[2]string]bool)
+	for _, group := range groups {
+		for _, driver := range group.Drivers {
+			if driver.Kind != "" && driver.Kind != storage.KindExecutable && driver.Kind != storage.KindMsi {
+				continue
+			}
+
+			name := strings.TrimSpace(driver.Metadata.ProductName)
+			version := cmp.Or(driver.Metadata.ProductVersion, driver.Metadata.FileVersion)
+			if name == "" || version == "" || compared[[2]string{strings.ToLower(name), version}] {
+				continue
+			}
+			compared[[2]string{strings.ToLower(name), version}] = true
+
+			c := DriverComparison{
+				ProductName:    name,
+				GroupId:        group.Id,
+				DriverId:       driver.Id,
+				DriverName:     driver.Name,
+				PackageVersion: version,
+				State:          NotInstalled,
+			}
+			for _, product := range products {
+				if !strings.EqualFold(product.Name, name) {
+					continue
+				}
+				if c.State != NotInstalled && installer.CompareVersions(product.Version, c.InstalledVersion) <= 0 {
+					continue
+				}
+
+				c.InstalledVersion, c.InstalledDate, c.Provider = product.Version, product.InstallDate, product.Publisher
+				switch installer.CompareVersions(c.InstalledVersion, c.PackageVersion) {
+				case -1:
+					c.State = Outdated
+				case 1:
+					c.State = Newer
+				default:
+					c.State = UpToDate
+				}
+			}
+			comparisons = append(comparisons, c)
+		}
+	}
+	return comparisons
+}
@@ -0,0 +1,54 @@
+package sysinfo
+
+import (
+	"driver-box/pkg/storage"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCompareProducts(t *testing.T) {
+	installed := time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC)
+	products := []InstalledProduct{
+		{Name: "Realtek Audio Driver", Version: "6.0.9549.1", Publisher: "Realtek Semiconductor Corp.", InstallDate: installed},
+		{Name: "Intel(R) Wireless Bluetooth(R)", Version: "23.40.0.2", Publisher: "Intel Corporation"},
+		{Name: "Intel(R) Wireless Bluetooth(R)", Version: "23.60.0.1", Publisher: "Intel Corporation"},
+		{Name: "NVIDIA Graphics Driver 552.22", Version: "552.22", Publisher: "NVIDIA Corporation"},
+	}
+
+	driver := func(id string, kind storage.DriverKind, name string, productVersion string, fileVersion string) storage.Driver {
+		return storage.Driver{Id: id, Name: id, Kind: kind, Metadata: storage.DriverMetadata{
+			ProductName: name, ProductVersion: productVersion, FileVersion: fileVersion,
+		}}
+	}
+	groups := []storage.DriverGroup{
+		{Id: "g1", Drivers: []storage.Driver{
+			driver("audio", storage.KindExecutable, "Realtek Audio Driver", "6.0.9600.1", ""),
+			driver("bluetooth", storage.KindMsi, "intel(r) wireless bluetooth(r)", "23.60.0.1", ""),
+			driver("display", "", "NVIDIA Graphics Driver", "560.94", ""),
+			driver("chipset", storage.KindExecutable, "", "", ""),
+		}},
+		{Id: "g2", Drivers: []storage.Driver{
+			driver("audio-old", storage.KindExecutable, "Realtek Audio Driver ", "", "6.0.9000.1"),
+			driver("audio-copy", storage.KindExecutable, "Realtek Audio Driver", "6.0.9600.1", ""),
+			driver("network", storage.KindInf, "Intel Ethernet", "12.19.2.45", ""),
+			driver("script", storage.KindScript, "Realtek Audio Driver", "7.0", ""),
+		}},
+	}
+
+	want := []DriverComparison{
+		{ProductName: "Realtek Audio Driver", GroupId: "g1", DriverId: "audio", DriverName: "audio",
+			InstalledVersion: "6.0.9549.1", InstalledDate: installed, Provider: "Realtek Semiconductor Corp.",
+			PackageVersion: "6.0.9600.1", State: Outdated},
+		{ProductName: "intel(r) wireless bluetooth(r)", GroupId: "g1", DriverId: "bluetooth", DriverName: "bluetooth",
+			InstalledVersion: "23.60.0.1", Provider: "Intel Corporation", PackageVersion: "23.60.0.1", State: UpToDate},
+		{ProductName: "NVIDIA Graphics Driver", GroupId: "g1", DriverId: "display", DriverName: "display",
+			PackageVersion: "560.94", State: NotInstalled},
+		{ProductName: "Realtek Audio Driver", GroupId: "g2", DriverId: "audio-old", DriverName: "audio-old",
+			InstalledVersion: "6.0.9549.1", InstalledDate: installed, Provider: "Realtek Semiconductor Corp.",
+			PackageVersion: "6.0.9000.1", State: Newer},
+	}
+	if got := compareProducts(groups, products); !reflect.DeepEqual(got, want) {
+		t.Errorf("compareProducts() =\n%+v\nwant\n%+v", got, want)
+	}
+}
@@ -0,0 +1,78 @@
+package sysinfo
+
+import (
+	"strings"
+	"time"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// InstalledProduct is a program registered in the uninstall keys of the registry.
+type InstalledProduct struct {
+	Name        string    `json:"name"`
+	Version     string    `json:"version"`
+	Publisher   string    `json:"publisher"`
+	InstallDate time.Time `json:"installDate"` // Zero if not recorded
+}
+
+// Uninstall keys of the 64-bit and 32-bit programs installed for all users, and of the programs of the current user.
+var uninstallKeys = []struct {
+	root registry.Key
+	path string
+}{
+	{registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\Windows\CurrentVersion\Uninstall`},
+	{registry.LOCAL_MACHINE, `SOFTWARE\WOW6432Node\Microsoft\Windows\CurrentVersion\Uninstall`},
+	{registry.CURRENT_USER, `SOFTWARE\Microsoft\Windows\CurrentVersion\Uninstall`},
+}
+
+// InstalledProducts lists the programs shown in the installed programs of Windows.
+// System components and updates of other programs are left out.
+func (i SysInfo) InstalledProducts() ([]InstalledProduct, error) {
+	var products []InstalledProduct
+	for _, uninstall := range uninstallKeys {
+		// a 32-bit build would otherwise be redirected to the keys of 32-bit programs
+		key, err := registry.OpenKey(uninstall.root, uninstall.path, registry.READ|registry.WOW64_64KEY)
+		if err != nil {
+			continue
+		}
+		names, err := key.ReadSubKeyNames(-1)
+		if err != nil {
+			key.Close()
+			return nil, err
+		}
+
+		for _, name := range names {
+			if product, ok := readProduct(key, name); ok {
+				products = append(products, product)
+			}
+		}
+		key.Close()
+	}
+	return products, nil
+}
+
+// Reads a subkey of an uninstall key, reporting false for entries not shown as programs.
+func readProduct(parent registry.Key, name string) (InstalledProduct, bool) {
+	key, err := registry.OpenKey(parent, name, registry.QUERY_VALUE|registry.WOW64_64KEY)
+	if err != nil {
+		return InstalledProduct{}, false
+	}
+	defer key.Close()
+
+	if component, _, err := key.GetIntegerValue("SystemComponent"); err == nil && component == 1 {
+		return InstalledProduct{}, false
+	}
+	if _, _, err := key.GetStringValue("ParentKeyName"); err == nil {
+		return InstalledProduct{}, false
+	}
+
+	var product InstalledProduct
+	product.Name, _, _ = key.GetStringValue("DisplayName")
+	product.Version, _, _ = key.GetStringValue("DisplayVersion")
+	product.Publisher, _, _ = key.GetStringValue("Publisher")
+	if date, _, err := key.GetStringValue("InstallDate"); err == nil {
+		product.InstallDate, _ = time.Parse("20060102", date)
+	}
+	product.Name = strings.TrimSpace(product.Name)
+	return product, product.Name != ""
+}
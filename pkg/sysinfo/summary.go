@@ -0,0 +1,334 @@
+package sysinfo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/yusufpapurcu/wmi"
+)
+
+// Hardware holds the raw WMI information a [Summary] is computed from.
+type Hardware struct {
+	Motherboards  []Win32_BaseBoard
+	Cpus          []Win32_Processor
+	Memory        []Win32_PhysicalMemory
+	Gpus          []Win32_VideoController
+	Nics          []Win32_NetworkAdapter
+	Disks         []Win32_DiskDrive
+	PhysicalDisks []MSFT_PhysicalDisk
+	VideoMemory   map[string]uint64 // Dedicated memory of display adapters, keyed by lowercased hardware IDs
+}
+
+// NicFilter selects the network adapters left out of a [Summary].
+type NicFilter struct {
+	Miniport  bool // Leaves out adapters whose names contain "Miniport", such as the WAN Miniport adapters
+	Microsoft bool // Leaves out adapters whose names contain "Microsoft", such as the kernel debugger and Wi-Fi Direct adapters
+}
+
+// Summary is the hardware information normalised for display, with capacities in human units and enum codes decoded.
+type Summary struct {
+	Motherboards []string      `json:"motherboards"`
+	Cpus         []CpuSummary  `json:"cpus"`
+	Memory       MemorySummary `json:"memory"`
+	Gpus         []GpuSummary  `json:"gpus"`
+	Nics         []NicSummary  `json:"nics"`
+	Disks        []DiskSummary `json:"disks"`
+}
+
+type CpuSummary struct {
+	Name    string `json:"name"`
+	Cores   uint32 `json:"cores"`
+	Threads uint32 `json:"threads"`
+}
+
+type MemorySummary struct {
+	Total     uint64         `json:"total"`
+	TotalText string         `json:"totalText"`
+	Modules   []MemoryModule `json:"modules"`
+}
+
+type MemoryModule struct {
+	Slot         string `json:"slot"`
+	Manufacturer string `json:"manufacturer"`
+	PartNumber   string `json:"partNumber"`
+	Capacity     uint64 `json:"capacity"`
+	CapacityText string `json:"capacityText"`
+	Type         string `json:"type"`       // e.g. DDR4, empty if unknown
+	FormFactor   string `json:"formFactor"` // e.g. SODIMM, empty if unknown
+	Speed        uint32 `json:"speed"`      // In MT/s
+}
+
+type GpuSummary struct {
+	Name          string `json:"name"`
+	Model         string `json:"model"` // Vendor and model names from the ID database, empty if unknown
+	Memory        uint64 `json:"memory"`
+	MemoryText    string `json:"memoryText"`
+	DriverVersion string `json:"driverVersion"`
+}
+
+type NicSummary struct {
+	Name      string `json:"name"`
+	MAC       string `json:"mac"`
+	Speed     uint64 `json:"speed"` // In bits per second, zero if unknown
+	SpeedText string `json:"speedText"`
+	Connected bool   `json:"connected"`
+	Physical  bool   `json:"physical"`
+}
+
+type DiskSummary struct {
+	Model    string `json:"model"`
+	Size     uint64 `json:"size"`
+	SizeText string `json:"sizeText"`
+	Media    string `json:"media"` // ssd, hdd or scm, empty if unknown
+	Bus      string `json:"bus"`   // e.g. nvme, sata or usb, empty if unknown
+}
+
+// Summary collects the hardware information with the network adapters filtered by the application setting.
+// The results cached by the collector are reused; failed queries are reported in the errors of the snapshot,
+// with the information of the others still summarised.
+func (i SysInfo) Summary() (Snapshot, error) {
+	return i.snapshot(i.collector())
+}
+
+// RefreshSummary collects the hardware information as [SysInfo.Summary], but with the cached results discarded.
+func (i SysInfo) RefreshSummary() (Snapshot, error) {
+	c := i.collector()
+	c.Reset()
+	return i.snapshot(c)
+}
+
+func (i SysInfo) PhysicalDiskInfo() ([]MSFT_PhysicalDisk, error) {
+	var cls []MSFT_PhysicalDisk
+	q := wmi.CreateQuery(&cls, "")
+	if err := wmiQuery(q, &cls, nil, `root\Microsoft\Windows\Storage`); err != nil {
+		return cls, err
+	}
+	return cls, nil
+}
+
+// Returns the network adapter filter of the application setting, if attached.
+func (i SysInfo) nicFilter() NicFilter {
+	if i.Settings == nil {
+		return NicFilter{}
+	}
+	setting, err := i.Settings.Read()
+	if err != nil {
+		return NicFilter{}
+	}
+	return NicFilter{Miniport: setting.FilterMiniportNic, Microsoft: setting.FilterMicrosoftNic}
+}
+
+// Summarise normalises the raw hardware information.
+func Summarise(hw Hardware, filter NicFilter) Summary {
+	var summary Summary
+
+	for _, board := range hw.Motherboards {
+		summary.Motherboards = append(summary.Motherboards, joinNonEmpty(board.Manufacturer, board.Product))
+	}
+
+	for _, cpu := range hw.Cpus {
+		summary.Cpus = append(summary.Cpus, CpuSummary{
+			Name:    strings.Join(strings.Fields(cpu.Name), " "),
+			Cores:   cpu.NumberOfCores,
+			Threads: cpu.NumberOfLogicalProcessors,
+		})
+	}
+
+	for _, mem := range hw.Memory {
+		speed := mem.ConfiguredClockSpeed
+		if speed == 0 {
+			speed = mem.Speed
+		}
+		summary.Memory.Total += mem.Capacity
+		summary.Memory.Modules = append(summary.Memory.Modules, MemoryModule{
+			Slot:         joinNonEmpty(mem.BankLabel, mem.DeviceLocator),
+			Manufacturer: strings.TrimSpace(mem.Manufacturer),
+			PartNumber:   strings.TrimSpace(mem.PartNumber),
+			Capacity:     mem.Capacity,
+			CapacityText: binarySize(mem.Capacity),
+			Type:         memoryType(mem),
+			FormFactor:   memoryFormFactors[mem.FormFactor],
+			Speed:        speed,
+		})
+	}
+	summary.Memory.TotalText = binarySize(summary.Memory.Total)
+
+	for _, gpu := range hw.Gpus {
+		memory := uint64(gpu.AdapterRAM)
+		for id, size := range hw.VideoMemory {
+			if strings.HasPrefix(strings.ToLower(gpu.PNPDeviceID), id) {
+				memory = size
+				break
+			}
+		}
+		summary.Gpus = append(summary.Gpus, GpuSummary{
+			Name:          strings.TrimSpace(gpu.Name),
+			Memory:        memory,
+			MemoryText:    binarySize(memory),
+			DriverVersion: gpu.DriverVersion,
+		})
+	}
+
+	for _, nic := range hw.Nics {
+		if filter.Miniport && strings.Contains(nic.Name, "Miniport") {
+			continue
+		}
+		if filter.Microsoft && strings.Contains(nic.Name, "Microsoft") {
+			continue
+		}
+
+		speed := nic.Speed
+		if speed == math.MaxInt64 {
+			// reported when the speed is unknown, usually when disconnected
+			speed = 0
+		}
+		summary.Nics = append(summary.Nics, NicSummary{
+			Name:      strings.TrimSpace(nic.Name),
+			MAC:       strings.ToUpper(nic.MACAddress),
+			Speed:     speed,
+			SpeedText: linkSpeed(speed),
+			Connected: nic.NetConnectionStatus == 2,
+			Physical:  nic.PhysicalAdapter,
+		})
+	}
+
+	for _, disk := range hw.Disks {
+		d := DiskSummary{
+			Model:    strings.TrimSpace(disk.Model),
+			Size:     disk.Size,
+			SizeText: decimalSize(disk.Size),
+			Bus:      strings.ToLower(disk.InterfaceType),
+		}
+		for _, physical := range hw.PhysicalDisks {
+			if physical.DeviceId == strconv.FormatUint(uint64(disk.Index), 10) {
+				d.Media = diskMediaTypes[physical.MediaType]
+				if bus, ok := diskBusTypes[physical.BusType]; ok {
+					d.Bus = bus
+				}
+				break
+			}
+		}
+		summary.Disks = append(summary.Disks, d)
+	}
+	return summary
+}
+
+// Memory types of SMBIOS, reported in SMBIOSMemoryType.
+var smbiosMemoryTypes = map[uint32]string{
+	15: "SDRAM",
+	18: "DDR",
+	19: "DDR2",
+	20: "DDR2 FB-DIMM",
+	24: "DDR3",
+	26: "DDR4",
+	27: "LPDDR",
+	28: "LPDDR2",
+	29: "LPDDR3",
+	30: "LPDDR4",
+	32: "HBM",
+	33: "HBM2",
+	34: "DDR5",
+	35: "LPDDR5",
+}
+
+// Memory types of CIM, reported in MemoryType by older systems.
+var cimMemoryTypes = map[uint16]string{
+	17: "SDRAM",
+	20: "DDR",
+	21: "DDR2",
+	22: "DDR2 FB-DIMM",
+	24: "DDR3",
+	26: "DDR4",
+}
+
+var memoryFormFactors = map[uint16]string{
+	7:  "SIMM",
+	8:  "DIMM",
+	11: "RIMM",
+	12: "SODIMM",
+	13: "SRIMM",
+}
+
+func memoryType(mem Win32_PhysicalMemory) string {
+	if t, ok := smbiosMemoryTypes[mem.SMBIOSMemoryType]; ok {
+		return t
+	}
+	return cimMemoryTypes[mem.MemoryType]
+}
+
+var diskMediaTypes = map[uint16]string{
+	3: "hdd",
+	4: "ssd",
+	5: "scm",
+}
+
+var diskBusTypes = map[uint16]string{
+	1:  "scsi",
+	2:  "atapi",
+	3:  "ata",
+	4:  "1394",
+	5:  "ssa",
+	6:  "fibre_channel",
+	7:  "usb",
+	8:  "raid",
+	9:  "iscsi",
+	10: "sas",
+	11: "sata",
+	12: "sd",
+	13: "mmc",
+	14: "virtual",
+	15: "file_backed_virtual",
+	16: "storage_spaces",
+	17: "nvme",
+	18: "scm",
+	19: "ufs",
+}
+
+// Formats a size in binary units, as Windows shows memory sizes, e.g. 16 GB for 16 GiB.
+func binarySize(n uint64) string {
+	return humanSize(n, 1024)
+}
+
+// Formats a size in decimal units, as drive manufacturers label their capacities.
+func decimalSize(n uint64) string {
+	return humanSize(n, 1000)
+}
+
+func humanSize(n uint64, base float64) string {
+	units := []string{"B", "KB", "MB", "GB", "TB", "PB"}
+
+	size, unit := float64(n), 0
+	for size >= base && unit < len(units)-1 {
+		size /= base
+		unit++
+	}
+	return strconv.FormatFloat(math.Round(size*10)/10, 'f', -1, 64) + " " + units[unit]
+}
+
+// Formats a link speed in bits per second, e.g. 2.5 Gbps.
+func linkSpeed(bps uint64) string {
+	if bps == 0 {
+		return ""
+	}
+
+	units := []string{"bps", "Kbps", "Mbps", "Gbps", "Tbps"}
+	speed, unit := float64(bps), 0
+	for speed >= 1000 && unit < len(units)-1 {
+		speed /= 1000
+		unit++
+	}
+	return fmt.Sprintf("%s %s", strconv.FormatFloat(math.Round(speed*10)/10, 'f', -1, 64), units[unit])
+}
+
+// Joins the trimmed non-empty strings with a space.
+func joinNonEmpty(ss ...string) string {
+	var parts []string
+	for _, s := range ss {
+		if s = strings.TrimSpace(s); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,51 @@
+package sysinfo
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSummariseNicFilter(t *testing.T) {
+	hw := Hardware{Nics: []Win32_NetworkAdapter{
+		{Name: "Intel(R) Ethernet Connection (7) I219-V", PhysicalAdapter: true},
+		{Name: "WAN Miniport (IKEv2)"},
+		{Name: "Microsoft Kernel Debug Network Adapter"},
+		{Name: "Microsoft Wi-Fi Direct Virtual Adapter"},
+		{Name: "Bluetooth Device (Personal Area Network)", PhysicalAdapter: false},
+		{Name: "Hyper-V Virtual Ethernet Adapter"},
+	}}
+
+	tests := []struct {
+		name   string
+		filter NicFilter
+		want   []string
+	}{
+		{"none", NicFilter{}, []string{
+			"Intel(R) Ethernet Connection (7) I219-V", "WAN Miniport (IKEv2)", "Microsoft Kernel Debug Network Adapter",
+			"Microsoft Wi-Fi Direct Virtual Adapter", "Bluetooth Device (Personal Area Network)", "Hyper-V Virtual Ethernet Adapter",
+		}},
+		// adapters that are not physical are kept unless named as miniports
+		{"miniport", NicFilter{Miniport: true}, []string{
+			"Intel(R) Ethernet Connection (7) I219-V", "Microsoft Kernel Debug Network Adapter",
+			"Microsoft Wi-Fi Direct Virtual Adapter", "Bluetooth Device (Personal Area Network)", "Hyper-V Virtual Ethernet Adapter",
+		}},
+		{"microsoft", NicFilter{Microsoft: true}, []string{
+			"Intel(R) Ethernet Connection (7) I219-V", "WAN Miniport (IKEv2)",
+			"Bluetooth Device (Personal Area Network)", "Hyper-V Virtual Ethernet Adapter",
+		}},
+		{"both", NicFilter{Miniport: true, Microsoft: true}, []string{
+			"Intel(R) Ethernet Connection (7) I219-V", "Bluetooth Device (Personal Area Network)", "Hyper-V Virtual Ethernet Adapter",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, nic := range Summarise(hw, tt.filter).Nics {
+				names = append(names, nic.Name)
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("adapters = %q, want %q", names, tt.want)
+			}
+		})
+	}
+}
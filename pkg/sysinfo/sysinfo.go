@@ -1,15 +1,37 @@
 package sysinfo
 
 import (
+	"driver-box/pkg/storage"
+
 	"github.com/yusufpapurcu/wmi"
 )
 
-type SysInfo struct{}
+type SysInfo struct {
+	DriverDir string // Directory searched for INF files
+	IDDir     string // Directory of the pci.ids and usb.ids databases
+	Groups    *storage.DriverGroupManager
+	Settings  *storage.AppSettingManager // Setting of the network adapter filters, no adapters are filtered if nil
+	Collector *Collector                 // Cache of the hardware summary, nothing is cached if nil
+	Resolve   func(path string) string   // Resolves the path of a driver to its file, the path is used as is if nil
+}
+
+// Runs the WQL query on a connection of its own, unlike [wmi.Query] which serialises every query
+// of the process behind one lock, so that a hanging query does not hold up the others.
+//
+// connectServerArgs are passed to SWbemLocator.ConnectServer, as in [wmi.Query].
+func wmiQuery(query string, dst any, connectServerArgs ...any) error {
+	services, err := wmi.InitializeSWbemServices(wmi.DefaultClient)
+	if err != nil {
+		return err
+	}
+	defer services.Close()
+	return services.Query(query, dst, connectServerArgs...)
+}
 
 func (i SysInfo) CpuInfo() ([]Win32_Processor, error) {
 	var cls []Win32_Processor
 	q := wmi.CreateQuery(&cls, "")
-	if err := wmi.Query(q, &cls); err != nil {
+	if err := wmiQuery(q, &cls); err != nil {
 		return cls, err
 	}
 	return cls, nil
@@ -18,7 +40,7 @@ func (i SysInfo) CpuInfo() ([]Win32_Processor, error) {
 func (i SysInfo) MotherboardInfo() ([]Win32_BaseBoard, error) {
 	var cls []Win32_BaseBoard
 	q := wmi.CreateQuery(&cls, "")
-	if err := wmi.Query(q, &cls); err != nil {
+	if err := wmiQuery(q, &cls); err != nil {
 		return cls, err
 	}
 	return cls, nil
@@ -27,7 +49,7 @@ func (i SysInfo) MotherboardInfo() ([]Win32_BaseBoard, error) {
 func (i SysInfo) MemoryInfo() ([]Win32_PhysicalMemory, error) {
 	var cls []Win32_PhysicalMemory
 	q := wmi.CreateQuery(&cls, "")
-	if err := wmi.Query(q, &cls); err != nil {
+	if err := wmiQuery(q, &cls); err != nil {
 		return cls, err
 	}
 	return cls, nil
@@ -36,7 +58,7 @@ func (i SysInfo) MemoryInfo() ([]Win32_PhysicalMemory, error) {
 func (i SysInfo) GpuInfo() ([]Win32_VideoController, error) {
 	var cls []Win32_VideoController
 	q := wmi.CreateQuery(&cls, "")
-	if err := wmi.Query(q, &cls); err != nil {
+	if err := wmiQuery(q, &cls); err != nil {
 		return cls, err
 	}
 	return cls, nil
@@ -45,7 +67,7 @@ func (i SysInfo) GpuInfo() ([]Win32_VideoController, error) {
 func (i SysInfo) NicInfo() ([]Win32_NetworkAdapter, error) {
 	var cls []Win32_NetworkAdapter
 	q := wmi.CreateQuery(&cls, "")
-	if err := wmi.Query(q, &cls); err != nil {
+	if err := wmiQuery(q, &cls); err != nil {
 		return cls, err
 	}
 	return cls, nil
@@ -54,7 +76,7 @@ func (i SysInfo) NicInfo() ([]Win32_NetworkAdapter, error) {
 func (i SysInfo) DiskInfo() ([]Win32_DiskDrive, error) {
 	var cls []Win32_DiskDrive
 	q := wmi.CreateQuery(&cls, "")
-	if err := wmi.Query(q, &cls); err != nil {
+	if err := wmiQuery(q, &cls); err != nil {
 		return cls, err
 	}
 	return cls, nil
@@ -63,7 +85,7 @@ func (i SysInfo) DiskInfo() ([]Win32_DiskDrive, error) {
 func (i SysInfo) DiskParitionInfo() ([]Win32_DiskPartition, error) {
 	var cls []Win32_DiskPartition
 	q := wmi.CreateQuery(&cls, "")
-	if err := wmi.Query(q, &cls); err != nil {
+	if err := wmiQuery(q, &cls); err != nil {
 		return cls, err
 	}
 	return cls, nil
@@ -72,7 +94,7 @@ func (i SysInfo) DiskParitionInfo() ([]Win32_DiskPartition, error) {
 func (i SysInfo) UserAccountInfo() ([]Win32_UserAccount, error) {
 	var cls []Win32_UserAccount
 	q := wmi.CreateQuery(&cls, "")
-	if err := wmi.Query(q, &cls); err != nil {
+	if err := wmiQuery(q, &cls); err != nil {
 		return cls, err
 	}
 	return cls, nil
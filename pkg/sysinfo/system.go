@@ -0,0 +1,277 @@
+package sysinfo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yusufpapurcu/wmi"
+)
+
+// Bios is the normalised information of a BIOS or UEFI firmware.
+type Bios struct {
+	Manufacturer      string    `json:"manufacturer"`
+	Version           string    `json:"version"`
+	ReleaseDate       time.Time `json:"releaseDate"`
+	SerialNumber      string    `json:"serialNumber"`
+	SMBIOSVersion     string    `json:"smbiosVersion"`
+	ControllerVersion string    `json:"controllerVersion"` // Version of the embedded controller firmware, empty if not reported
+}
+
+// OperatingSystem is the normalised information of the installed Windows.
+type OperatingSystem struct {
+	Name           string    `json:"name"`    // Caption without the "Microsoft" prefix, e.g. Windows 11 Pro
+	Edition        string    `json:"edition"` // Edition decoded from the SKU, empty if unknown
+	Version        string    `json:"version"`
+	Build          string    `json:"build"`
+	Architecture   string    `json:"architecture"`
+	ProductType    string    `json:"productType"` // workstation, domain_controller or server
+	SKU            uint32    `json:"sku"`
+	ProductID      string    `json:"productId"`
+	RegisteredUser string    `json:"registeredUser"`
+	Organization   string    `json:"organization"`
+	Locale         string    `json:"locale"`
+	InstallDate    time.Time `json:"installDate"`
+	LastBootUpTime time.Time `json:"lastBootUpTime"`
+}
+
+// Battery is the normalised information of a battery.
+type Battery struct {
+	Name               string `json:"name"`
+	DeviceID           string `json:"deviceId"`
+	Chemistry          string `json:"chemistry"`
+	Status             string `json:"status"`
+	ChargeRemaining    uint16 `json:"chargeRemaining"`    // Percentage of the full charge remaining
+	DesignCapacity     uint32 `json:"designCapacity"`     // In mWh, zero if not reported
+	FullChargeCapacity uint32 `json:"fullChargeCapacity"` // In mWh, zero if not reported
+	Health             uint16 `json:"health"`             // Full charge capacity as a percentage of the design capacity, zero if unknown
+}
+
+// SoundDevice is the normalised information of an audio device.
+type SoundDevice struct {
+	Name         string `json:"name"`
+	Manufacturer string `json:"manufacturer"`
+	DeviceID     string `json:"deviceId"`
+	Status       string `json:"status"`
+}
+
+// Monitor is the normalised identification of a monitor from its EDID.
+type Monitor struct {
+	Name         string `json:"name"`
+	Manufacturer string `json:"manufacturer"` // Three-letter PnP ID of the manufacturer, e.g. DEL
+	ProductCode  string `json:"productCode"`
+	SerialNumber string `json:"serialNumber"`
+	Year         uint16 `json:"year"` // Year of manufacture
+	Week         uint8  `json:"week"` // Week of manufacture, zero if not reported
+	Active       bool   `json:"active"`
+}
+
+func (i SysInfo) BiosInfo() ([]Bios, error) {
+	var cls []Win32_BIOS
+	q := wmi.CreateQuery(&cls, "")
+	if err := wmiQuery(q, &cls); err != nil {
+		return nil, err
+	}
+	return NormaliseBios(cls), nil
+}
+
+func (i SysInfo) OsInfo() ([]OperatingSystem, error) {
+	var cls []Win32_OperatingSystem
+	q := wmi.CreateQuery(&cls, "")
+	if err := wmiQuery(q, &cls); err != nil {
+		return nil, err
+	}
+	return NormaliseOperatingSystem(cls), nil
+}
+
+func (i SysInfo) BatteryInfo() ([]Battery, error) {
+	var cls []Win32_Battery
+	q := wmi.CreateQuery(&cls, "")
+	if err := wmiQuery(q, &cls); err != nil {
+		return nil, err
+	}
+	return NormaliseBattery(cls), nil
+}
+
+func (i SysInfo) SoundInfo() ([]SoundDevice, error) {
+	var cls []Win32_SoundDevice
+	q := wmi.CreateQuery(&cls, "")
+	if err := wmiQuery(q, &cls); err != nil {
+		return nil, err
+	}
+	return NormaliseSoundDevice(cls), nil
+}
+
+func (i SysInfo) MonitorInfo() ([]Monitor, error) {
+	var cls []WmiMonitorID
+	q := wmi.CreateQuery(&cls, "")
+	if err := wmiQuery(q, &cls, nil, `root\wmi`); err != nil {
+		return nil, err
+	}
+	return NormaliseMonitor(cls), nil
+}
+
+func NormaliseBios(cls []Win32_BIOS) []Bios {
+	bios := make([]Bios, 0, len(cls))
+	for _, c := range cls {
+		b := Bios{
+			Manufacturer: strings.TrimSpace(c.Manufacturer),
+			Version:      strings.TrimSpace(c.SMBIOSBIOSVersion),
+			ReleaseDate:  c.ReleaseDate,
+			SerialNumber: strings.TrimSpace(c.SerialNumber),
+		}
+		if b.Version == "" {
+			b.Version = strings.TrimSpace(c.Version)
+		}
+		if c.SMBIOSPresent {
+			b.SMBIOSVersion = fmt.Sprintf("%d.%d", c.SMBIOSMajorVersion, c.SMBIOSMinorVersion)
+		}
+		// 255 stands for no embedded controller firmware
+		if c.EmbeddedControllerMajorVersion != 255 && c.EmbeddedControllerMajorVersion != 0 {
+			b.ControllerVersion = fmt.Sprintf("%d.%d", c.EmbeddedControllerMajorVersion, c.EmbeddedControllerMinorVersion)
+		}
+		bios = append(bios, b)
+	}
+	return bios
+}
+
+// Editions by their SKU numbers.
+//
+// See: https://learn.microsoft.com/en-us/windows/win32/api/sysinfoapi/nf-sysinfoapi-getproductinfo
+var editions = map[uint32]string{
+	4:   "Enterprise",
+	7:   "Server Standard",
+	8:   "Server Datacenter",
+	27:  "Enterprise N",
+	48:  "Pro",
+	49:  "Pro N",
+	98:  "Home N",
+	99:  "Home China",
+	100: "Home Single Language",
+	101: "Home",
+	121: "Education",
+	122: "Education N",
+	125: "Enterprise LTSC",
+	126: "Enterprise N LTSC",
+	161: "Pro for Workstations",
+	162: "Pro N for Workstations",
+	164: "Pro Education",
+	165: "Pro Education N",
+	175: "Enterprise multi-session",
+	188: "IoT Enterprise",
+	191: "IoT Enterprise LTSC",
+}
+
+var productTypes = map[uint32]string{
+	1: "workstation",
+	2: "domain_controller",
+	3: "server",
+}
+
+func NormaliseOperatingSystem(cls []Win32_OperatingSystem) []OperatingSystem {
+	systems := make([]OperatingSystem, 0, len(cls))
+	for _, c := range cls {
+		systems = append(systems, OperatingSystem{
+			Name:           strings.TrimSpace(strings.TrimPrefix(c.Caption, "Microsoft ")),
+			Edition:        editions[c.OperatingSystemSKU],
+			Version:        c.Version,
+			Build:          c.BuildNumber,
+			Architecture:   c.OSArchitecture,
+			ProductType:    productTypes[c.ProductType],
+			SKU:            c.OperatingSystemSKU,
+			ProductID:      c.SerialNumber,
+			RegisteredUser: strings.TrimSpace(c.RegisteredUser),
+			Organization:   strings.TrimSpace(c.Organization),
+			Locale:         c.Locale,
+			InstallDate:    c.InstallDate,
+			LastBootUpTime: c.LastBootUpTime,
+		})
+	}
+	return systems
+}
+
+var batteryChemistries = map[uint16]string{
+	1: "other",
+	2: "unknown",
+	3: "lead_acid",
+	4: "nickel_cadmium",
+	5: "nickel_metal_hydride",
+	6: "lithium_ion",
+	7: "zinc_air",
+	8: "lithium_polymer",
+}
+
+var batteryStatuses = map[uint16]string{
+	1:  "discharging",
+	2:  "ac", // on AC power, not necessarily charging
+	3:  "fully_charged",
+	4:  "low",
+	5:  "critical",
+	6:  "charging",
+	7:  "charging_high",
+	8:  "charging_low",
+	9:  "charging_critical",
+	10: "undefined",
+	11: "partially_charged",
+}
+
+func NormaliseBattery(cls []Win32_Battery) []Battery {
+	batteries := make([]Battery, 0, len(cls))
+	for _, c := range cls {
+		b := Battery{
+			Name:               strings.TrimSpace(c.Name),
+			DeviceID:           strings.TrimSpace(c.DeviceID),
+			Chemistry:          batteryChemistries[c.Chemistry],
+			Status:             batteryStatuses[c.BatteryStatus],
+			ChargeRemaining:    c.EstimatedChargeRemaining,
+			DesignCapacity:     c.DesignCapacity,
+			FullChargeCapacity: c.FullChargeCapacity,
+		}
+		if c.DesignCapacity > 0 && c.FullChargeCapacity > 0 {
+			b.Health = uint16(min(uint64(c.FullChargeCapacity)*100/uint64(c.DesignCapacity), 100))
+		}
+		batteries = append(batteries, b)
+	}
+	return batteries
+}
+
+func NormaliseSoundDevice(cls []Win32_SoundDevice) []SoundDevice {
+	devices := make([]SoundDevice, 0, len(cls))
+	for _, c := range cls {
+		devices = append(devices, SoundDevice{
+			Name:         strings.TrimSpace(c.Name),
+			Manufacturer: strings.TrimSpace(c.Manufacturer),
+			DeviceID:     c.PNPDeviceID,
+			Status:       c.Status,
+		})
+	}
+	return devices
+}
+
+func NormaliseMonitor(cls []WmiMonitorID) []Monitor {
+	monitors := make([]Monitor, 0, len(cls))
+	for _, c := range cls {
+		monitors = append(monitors, Monitor{
+			Name:         edidString(c.UserFriendlyName),
+			Manufacturer: edidString(c.ManufacturerName),
+			ProductCode:  edidString(c.ProductCodeID),
+			SerialNumber: edidString(c.SerialNumberID),
+			Year:         c.YearOfManufacture,
+			Week:         c.WeekOfManufacture,
+			Active:       c.Active,
+		})
+	}
+	return monitors
+}
+
+// Decodes a string of WmiMonitorID, which is an array of character codes padded with zeros.
+func edidString(codes []int32) string {
+	var b strings.Builder
+	for _, c := range codes {
+		if c <= 0 || c > 0xFFFF {
+			break
+		}
+		b.WriteRune(rune(uint16(c)))
+	}
+	return strings.TrimSpace(b.String())
+}
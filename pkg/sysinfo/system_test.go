@@ -0,0 +1,99 @@
+package sysinfo
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Raw WMI objects of testdata/system.json, as decoded by the wmi package.
+type systemFixture struct {
+	Win32_BIOS            []Win32_BIOS
+	Win32_OperatingSystem []Win32_OperatingSystem
+	Win32_Battery         []Win32_Battery
+	Win32_SoundDevice     []Win32_SoundDevice
+	WmiMonitorID          []WmiMonitorID
+}
+
+func readSystemFixture(t *testing.T) systemFixture {
+	t.Helper()
+	b, err := os.ReadFile("testdata/system.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixture systemFixture
+	if err := json.Unmarshal(b, &fixture); err != nil {
+		t.Fatal(err)
+	}
+	return fixture
+}
+
+func date(year int, month time.Month, day int, hour int, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestNormalise(t *testing.T) {
+	fixture := readSystemFixture(t)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"BIOS", NormaliseBios(fixture.Win32_BIOS), []Bios{
+			{Manufacturer: "American Megatrends Inc.", Version: "1402", ReleaseDate: date(2023, 11, 2, 0, 0),
+				SerialNumber: "System Serial Number", SMBIOSVersion: "3.5"},
+			{Manufacturer: "LENOVO", Version: "LENOVO - 1270", ReleaseDate: date(2022, 6, 15, 0, 0),
+				SerialNumber: "PF3ABCDE", ControllerVersion: "1.39"},
+		}},
+		{"operating system", NormaliseOperatingSystem(fixture.Win32_OperatingSystem), []OperatingSystem{
+			{Name: "Windows 11 Pro", Edition: "Pro", Version: "10.0.22631", Build: "22631", Architecture: "64-bit",
+				ProductType: "workstation", SKU: 48, ProductID: "00330-80000-00000-AA123", RegisteredUser: "user",
+				Locale: "0c04", InstallDate: date(2024, 1, 20, 8, 30), LastBootUpTime: date(2024, 5, 14, 9, 0)},
+			{Name: "Windows Server 2022 Datacenter", Version: "10.0.20348", Build: "20348", Architecture: "64-bit",
+				ProductType: "server", SKU: 9999},
+		}},
+		{"battery", NormaliseBattery(fixture.Win32_Battery), []Battery{
+			{Name: "DELL 7FHHV12", DeviceID: "2413SMPDELL 7FHHV12", Chemistry: "lithium_ion", Status: "ac",
+				ChargeRemaining: 87, DesignCapacity: 54000, FullChargeCapacity: 45900, Health: 85},
+			{Name: "Internal Battery", DeviceID: "1", Chemistry: "unknown", Status: "discharging", ChargeRemaining: 40},
+			{Name: "Recalibrated Battery", DeviceID: "2", Chemistry: "lithium_polymer", ChargeRemaining: 100,
+				DesignCapacity: 50000, FullChargeCapacity: 51000, Health: 100},
+		}},
+		{"sound device", NormaliseSoundDevice(fixture.Win32_SoundDevice), []SoundDevice{
+			{Name: "Realtek High Definition Audio", Manufacturer: "Realtek",
+				DeviceID: `HDAUDIO\FUNC_01&VEN_10EC&DEV_0897&SUBSYS_104387FB&REV_1003\4&1B6D1E3E&0&0001`, Status: "OK"},
+		}},
+		{"monitor", NormaliseMonitor(fixture.WmiMonitorID), []Monitor{
+			{Name: "DELL U2720Q", Manufacturer: "DEL", ProductCode: "A1A8", SerialNumber: "7PRB4T3", Year: 2021, Week: 12, Active: true},
+			{Manufacturer: "BOE", ProductCode: "0A81", SerialNumber: "0", Year: 2020},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got\n%+v\nwant\n%+v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEdidString(t *testing.T) {
+	tests := []struct {
+		codes []int32
+		want  string
+	}{
+		{nil, ""},
+		{[]int32{68, 69, 76, 0, 0}, "DEL"},
+		{[]int32{32, 85, 50, 55, 50, 48, 81, 32, 0}, "U2720Q"},
+		{[]int32{65, 66, 0, 67}, "AB"},
+		{[]int32{65, -1, 66}, "A"},
+	}
+	for _, tt := range tests {
+		if got := edidString(tt.codes); got != tt.want {
+			t.Errorf("edidString(%v) = %q, want %q", tt.codes, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package sysinfo
+
+import (
+	"encoding/binary"
+	"strings"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// Registry key of the display adapter class, whose subkeys hold the settings of each adapter.
+const displayClassKey = `SYSTEM\CurrentControlSet\Control\Class\{4d36e968-e325-11ce-bfc1-08002be10318}`
+
+// Reads the dedicated memory sizes of display adapters recorded by their drivers,
+// keyed by the lowercased hardware IDs the drivers were matched by.
+// Unlike the AdapterRAM of Win32_VideoController, the sizes are not capped at 4 GB.
+func videoMemory() map[string]uint64 {
+	sizes := make(map[string]uint64)
+
+	class, err := registry.OpenKey(registry.LOCAL_MACHINE, displayClassKey, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		return sizes
+	}
+	defer class.Close()
+
+	names, err := class.ReadSubKeyNames(-1)
+	if err != nil {
+		return sizes
+	}
+
+	for _, name := range names {
+		key, err := registry.OpenKey(class, name, registry.QUERY_VALUE)
+		if err != nil {
+			continue
+		}
+
+		if id, _, err := key.GetStringValue("MatchingDeviceId"); err == nil {
+			if size, _, err := key.GetIntegerValue("HardwareInformation.qwMemorySize"); err == nil {
+				sizes[strings.ToLower(id)] = size
+			} else if size, _, err := key.GetIntegerValue("HardwareInformation.MemorySize"); err == nil {
+				sizes[strings.ToLower(id)] = size
+			} else if b, _, err := key.GetBinaryValue("HardwareInformation.MemorySize"); err == nil && len(b) >= 4 {
+				sizes[strings.ToLower(id)] = uint64(binary.LittleEndian.Uint32(b))
+			}
+		}
+		key.Close()
+	}
+	return sizes
+}
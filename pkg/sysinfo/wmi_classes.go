@@ -425,3 +425,256 @@ type Win32_UserAccount struct {
 	SIDType            uint8
 	Status             string
 }
+
+/*
+The Win32_PnPEntity WMI class represents the properties of a Plug and Play device.
+
+See: https://learn.microsoft.com/en-us/windows/win32/cimwin32prov/win32-pnpentity
+*/
+type Win32_PnPEntity struct {
+	Availability                uint16
+	Caption                     string
+	ClassGuid                   string
+	CompatibleID                []string
+	ConfigManagerErrorCode      uint32
+	ConfigManagerUserConfig     bool
+	CreationClassName           string
+	Description                 string
+	DeviceID                    string
+	ErrorCleared                bool
+	ErrorDescription            string
+	HardwareID                  []string
+	InstallDate                 time.Time
+	LastErrorCode               uint32
+	Manufacturer                string
+	Name                        string
+	PNPClass                    string
+	PNPDeviceID                 string
+	PowerManagementCapabilities []int32 // []uint16 causes panic, WMI returns the array as VT_I4
+	PowerManagementSupported    bool
+	Present                     bool
+	Service                     string
+	Status                      string
+	StatusInfo                  uint16
+	SystemCreationClassName     string
+	SystemName                  string
+}
+
+/*
+The Win32_PnPSignedDriver WMI class provides digital signature information about drivers.
+
+See: https://learn.microsoft.com/en-us/previous-versions/windows/desktop/legacy/aa394354(v=vs.85)
+*/
+type Win32_PnPSignedDriver struct {
+	Caption                 string
+	ClassGuid               string
+	CompatID                string
+	CreationClassName       string
+	Description             string
+	DeviceClass             string
+	DeviceID                string
+	DeviceName              string
+	DevLoader               string
+	DriverDate              time.Time
+	DriverName              string
+	DriverProviderName      string
+	DriverVersion           string
+	FriendlyName            string
+	HardWareID              string
+	InfName                 string
+	InstallDate             time.Time
+	IsSigned                bool
+	Location                string
+	Manufacturer            string
+	Name                    string
+	PDO                     string
+	Signer                  string
+	Started                 bool
+	StartMode               string
+	Status                  string
+	SystemCreationClassName string
+	SystemName              string
+}
+
+/*
+The Win32_BIOS WMI class represents the attributes of the computer system's basic input/output services (BIOS) that are installed on a computer.
+
+See: https://learn.microsoft.com/en-us/windows/win32/cimwin32prov/win32-bios
+*/
+type Win32_BIOS struct {
+	BIOSVersion                    []string
+	BuildNumber                    string
+	Caption                        string
+	CurrentLanguage                string
+	Description                    string
+	EmbeddedControllerMajorVersion uint8
+	EmbeddedControllerMinorVersion uint8
+	Manufacturer                   string
+	Name                           string
+	PrimaryBIOS                    bool
+	ReleaseDate                    time.Time
+	SerialNumber                   string
+	SMBIOSBIOSVersion              string
+	SMBIOSMajorVersion             uint16
+	SMBIOSMinorVersion             uint16
+	SMBIOSPresent                  bool
+	SoftwareElementID              string
+	SoftwareElementState           uint16
+	Status                         string
+	SystemBiosMajorVersion         uint8
+	SystemBiosMinorVersion         uint8
+	TargetOperatingSystem          uint16
+	Version                        string
+}
+
+/*
+The Win32_OperatingSystem WMI class represents a Windows-based operating system installed on a computer.
+
+See: https://learn.microsoft.com/en-us/windows/win32/cimwin32prov/win32-operatingsystem
+*/
+type Win32_OperatingSystem struct {
+	BootDevice                        string
+	BuildNumber                       string
+	BuildType                         string
+	Caption                           string
+	CodeSet                           string
+	CountryCode                       string
+	CSName                            string
+	CurrentTimeZone                   int16
+	DataExecutionPrevention_Available bool
+	Description                       string
+	EncryptionLevel                   uint32
+	FreePhysicalMemory                uint64
+	FreeVirtualMemory                 uint64
+	InstallDate                       time.Time
+	LastBootUpTime                    time.Time
+	LocalDateTime                     time.Time
+	Locale                            string
+	Manufacturer                      string
+	MUILanguages                      []string
+	Name                              string
+	NumberOfProcesses                 uint32
+	NumberOfUsers                     uint32
+	OperatingSystemSKU                uint32
+	Organization                      string
+	OSArchitecture                    string
+	OSLanguage                        uint32
+	OSProductSuite                    uint32
+	OSType                            uint16
+	PortableOperatingSystem           bool
+	Primary                           bool
+	ProductType                       uint32
+	RegisteredUser                    string
+	SerialNumber                      string
+	ServicePackMajorVersion           uint16
+	ServicePackMinorVersion           uint16
+	Status                            string
+	SuiteMask                         uint32
+	SystemDevice                      string
+	SystemDirectory                   string
+	SystemDrive                       string
+	TotalVirtualMemorySize            uint64
+	TotalVisibleMemorySize            uint64
+	Version                           string
+	WindowsDirectory                  string
+}
+
+/*
+The Win32_Battery WMI class represents a battery connected to the computer system.
+
+See: https://learn.microsoft.com/en-us/windows/win32/cimwin32prov/win32-battery
+*/
+type Win32_Battery struct {
+	Availability             uint16
+	BatteryRechargeTime      uint32
+	BatteryStatus            uint16
+	Caption                  string
+	Chemistry                uint16
+	ConfigManagerErrorCode   uint32
+	Description              string
+	DesignCapacity           uint32
+	DesignVoltage            uint64
+	DeviceID                 string
+	EstimatedChargeRemaining uint16
+	EstimatedRunTime         uint32
+	ExpectedLife             uint32
+	FullChargeCapacity       uint32
+	MaxRechargeTime          uint32
+	Name                     string
+	PNPDeviceID              string
+	Status                   string
+	TimeOnBattery            uint32
+	TimeToFullCharge         uint32
+}
+
+/*
+The Win32_SoundDevice WMI class represents the properties of a sound device on a computer system running Windows.
+
+See: https://learn.microsoft.com/en-us/windows/win32/cimwin32prov/win32-sounddevice
+*/
+type Win32_SoundDevice struct {
+	Availability           uint16
+	Caption                string
+	ConfigManagerErrorCode uint32
+	Description            string
+	DeviceID               string
+	DMABufferSize          uint16
+	Manufacturer           string
+	MPU401Address          uint32
+	Name                   string
+	PNPDeviceID            string
+	ProductName            string
+	Status                 string
+	StatusInfo             uint16
+}
+
+/*
+The WmiMonitorID WMI class in the root\wmi namespace represents the identification data of a monitor, read from its EDID.
+The strings are arrays of character codes padded with zeros, which WMI returns as VT_I4 whatever their declared type.
+
+See: https://learn.microsoft.com/en-us/windows/win32/wmicoreprov/wmimonitorid
+*/
+type WmiMonitorID struct {
+	Active                 bool
+	InstanceName           string
+	ManufacturerName       []int32
+	ProductCodeID          []int32
+	SerialNumberID         []int32
+	UserFriendlyName       []int32
+	UserFriendlyNameLength uint16
+	WeekOfManufacture      uint8
+	YearOfManufacture      uint16
+}
+
+/*
+The MSFT_PhysicalDisk WMI class in the root\Microsoft\Windows\Storage namespace represents a physical disk on a storage subsystem.
+Array properties are left out, as their elements are reported as signed integers.
+
+See: https://learn.microsoft.com/en-us/windows-hardware/drivers/storage/msft-physicaldisk
+*/
+type MSFT_PhysicalDisk struct {
+	AllocatedSize       uint64
+	BusType             uint16
+	CanPool             bool
+	Description         string
+	DeviceId            string
+	EnclosureNumber     uint16
+	FirmwareVersion     string
+	FriendlyName        string
+	HealthStatus        uint16
+	IsIndicationEnabled bool
+	IsPartial           bool
+	LogicalSectorSize   uint64
+	MediaType           uint16
+	Model               string
+	ObjectId            string
+	PhysicalLocation    string
+	PhysicalSectorSize  uint64
+	SerialNumber        string
+	Size                uint64
+	SlotNumber          uint16
+	SoftwareVersion     string
+	SpindleSpeed        uint32
+	UniqueId            string
+	Usage               uint16
+}